@@ -2,49 +2,257 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"math-parser/pkg/entity"
 	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/prelude"
 	syntactical_analyzer "math-parser/pkg/syntactical_analysis"
 	"math-parser/pkg/utils/logging"
+	"os"
 	"strings"
+	"time"
 )
 
 func main() {
 	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
 
-	automata := lexical_analysis.NewAutomata()
-	lexicalAnalyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
-	syntacticalAnalyzer := syntactical_analyzer.NewLL1PredictableParser(ctx)
+	config := lexical_analysis.DefaultConfig()
+	flag.BoolVar(&config.Identifiers.SingleLetter, "single-letter", false, "lex every letter as a variable of its own")
+	flag.BoolVar(&config.Identifiers.Underscores, "underscores", false, "allow underscores inside variable names")
+	flag.BoolVar(&config.Primitives, "primitives", false, "read integer literals and +, -, *, == and if, evaluated natively by δ-rules")
+
+	parserConfig := syntactical_analyzer.DefaultConfig()
+	flag.BoolVar(&parserConfig.Lenient, "lenient", false, "ignore the input following the first complete term")
+	flag.BoolVar(&parserConfig.Juxtaposition, "juxtaposition", false, "write applications as f x y instead of f_x_y")
+	flag.BoolVar(&parserConfig.RightAssociative, "right-assoc", false, "read x_y_z as x_(y_z) instead of (x_y)_z")
+
+	var typecheck bool
+	flag.BoolVar(&typecheck, "typecheck", false, "type the expression in the simply typed lambda calculus before reducing it")
+
+	var infer bool
+	flag.BoolVar(&infer, "infer", false, "infer the principal type of the expression by Hindley–Milner inference")
+
+	var pretty bool
+	flag.BoolVar(&pretty, "pretty", false, "print terms with as few brackets as possible")
+
+	printOptions := syntactical_analyzer.PrintOptions{}
+	flag.BoolVar(&printOptions.Ascii, "ascii", false, "print \\ instead of λ, together with --pretty")
+	flag.BoolVar(&printOptions.Sugar, "sugar", false, "print λx y.M instead of λx.λy.M, together with --pretty")
+	flag.IntVar(&printOptions.Width, "width", 0, "break terms longer than width over several lines, together with --pretty")
 
 	var expr string
 	flag.StringVar(&expr, "expr", "", "expression")
 
+	var file string
+	flag.StringVar(&file, "file", "", "file with the expression, used instead of --expr")
+
+	var usePrelude bool
+	flag.BoolVar(&usePrelude, "prelude", false, "define the Church encodings of the prelude, numerals like 42 included")
+
+	var defs string
+	flag.StringVar(&defs, "defs", "", "file with definitions name = term, whose names may be used in the expression")
+
+	var references bool
+	flag.BoolVar(&references, "references", false, "keep the names of --defs until they are applied instead of expanding them first")
+
 	var red string
-	flag.StringVar(&red, "red", "", "reduction")
+	flag.StringVar(&red, "red", "", "reduction: alpha, beta, delta, normal, applicative, cbn, cbv, cbneed, eta or betaeta")
+
+	var decode string
+	flag.StringVar(&decode, "decode", "", "decode the normal form as nat, scott-nat, bool, list, scott-list, pair, sum or a shape like list(nat)")
+
+	var trace bool
+	flag.BoolVar(&trace, "trace", false, "print every step of the reduction selected by --red (normal by default)")
+
+	var steps int
+	flag.IntVar(&steps, "steps", syntactical_analyzer.DEFAULT_MAX_STEPS, "maximum number of reduction steps")
+
+	var timeout time.Duration
+	flag.DurationVar(&timeout, "timeout", 0, "maximum duration of the reduction")
+
+	var deBruijn bool
+	flag.BoolVar(&deBruijn, "debruijn", false, "print the expression with de Bruijn indices")
 
 	var subInput string
 	flag.StringVar(&subInput, "sub", "", "substitution")
 
 	flag.Parse()
+	config.Identifiers.Numerals = config.Identifiers.Numerals || usePrelude
+	parserConfig.KeepLet = parserConfig.KeepLet || typecheck || infer
+
+	var shape syntactical_analyzer.Shape
+	if decode != "" {
+		var err error
+		if shape, err = syntactical_analyzer.ParseShape(decode); err != nil {
+			fmt.Printf("error: %s", err)
+			return
+		}
+		if red == "" {
+			red = syntactical_analyzer.NORMAL_ORDER
+		}
+		// Only the strategies normalize the expression, and --trace and --debruijn print terms instead.
+		if red == "alpha" || red == "beta" || red == "delta" {
+			fmt.Printf("error: --decode needs a reduction to normal form, not --red=%s", red)
+			return
+		}
+		if trace || deBruijn {
+			fmt.Print("error: --decode cannot be used with --trace or --debruijn")
+			return
+		}
+	}
+
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Printf("error: %s", err)
+			return
+		}
+		expr = string(content)
+	}
+
+	automata := lexical_analysis.NewConfiguredAutomata(config)
+	lexicalAnalyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	syntacticalAnalyzer := syntactical_analyzer.NewConfiguredLL1PredictableParser(ctx, parserConfig)
+	printOptions.Juxtaposition = parserConfig.Juxtaposition
+	printOptions.RightAssociative = parserConfig.RightAssociative
+	unparse := syntacticalAnalyzer.Unparse
+	if pretty {
+		unparse = func(ast entity.Ast) (string, error) {
+			return syntactical_analyzer.Print(ast, printOptions)
+		}
+	}
 
 	tk, err := lexicalAnalyzer.Tokenize(expr)
 	if err != nil {
-		fmt.Printf("error: %s", err)
+		printError(err, expr)
 		return
 	}
 
 	ast, err := syntacticalAnalyzer.Parse(tk)
 	if err != nil {
-		fmt.Printf("error: %s", err)
+		printError(err, expr)
 		return
 	}
 
-	if res, err := syntacticalAnalyzer.Unparse(ast); err != nil {
+	source := ast
+	var definitions syntactical_analyzer.Definitions
+	if defs != "" || usePrelude {
+		var content []byte
+		if defs != "" {
+			content, err = os.ReadFile(defs)
+			if err != nil {
+				fmt.Printf("error: %s", err)
+				return
+			}
+			tk, err := lexicalAnalyzer.Tokenize(string(content))
+			if err != nil {
+				printError(err, string(content))
+				return
+			}
+			definitions, err = syntacticalAnalyzer.ParseDefinitions(tk)
+			if err != nil {
+				printError(err, string(content))
+				return
+			}
+		}
+		if usePrelude {
+			library, err := prelude.Load(ctx)
+			if err != nil {
+				fmt.Printf("error: %s", err)
+				return
+			}
+			var terms []entity.Term
+			if !config.Primitives {
+				if _, err := library.Definitions(ast.Term()); err != nil {
+					printError(err, expr)
+					return
+				}
+				terms = append(terms, ast.Term())
+				for _, definition := range definitions {
+					terms = append(terms, definition.Term)
+				}
+			}
+			numerals, err := library.Definitions(terms...)
+			if err != nil {
+				printError(err, string(content))
+				return
+			}
+			definitions = append(numerals, definitions...)
+		}
+		if err := definitions.Check(); err != nil {
+			printError(err, string(content))
+			return
+		}
+		if !references {
+			ast = definitions.Expand(ast)
+		}
+	}
+	if typecheck || infer {
+		// The names of --defs and --prelude are typed as the terms they stand for, even with --references.
+		checked := definitions.Expand(source)
+		if typecheck {
+			derivation, err := syntacticalAnalyzer.TypeCheck(checked)
+			if err != nil {
+				printError(err, expr)
+				return
+			}
+			fmt.Println(derivation)
+		}
+		if infer {
+			typ, err := syntacticalAnalyzer.Infer(checked)
+			if err != nil {
+				printError(err, expr)
+				return
+			}
+			term, err := syntactical_analyzer.Print(source, printOptions)
+			if err != nil {
+				fmt.Printf("error: %s", err)
+				return
+			}
+			fmt.Printf("%s : %v\n", term, typ)
+		}
+		if red == "" && !trace {
+			return
+		}
+		ast = syntactical_analyzer.Desugar(ast)
+	}
+	newStrategy := func(name string) (syntactical_analyzer.ReductionStrategy, error) {
+		newReductionStrategy := syntactical_analyzer.NewReductionStrategy
+		if config.Primitives {
+			newReductionStrategy = syntactical_analyzer.NewPrimitiveStrategy
+		}
+		strategy, err := newReductionStrategy(name)
+		if err != nil || !references {
+			return strategy, err
+		}
+		return syntactical_analyzer.NewReferenceStrategy(strategy, definitions), nil
+	}
+
+	if res, err := unparse(ast); err != nil {
 		fmt.Printf("Unparsed to %s", res)
 		return
 	}
 
+	if deBruijn {
+		term, free, err := syntactical_analyzer.ToDeBruijn(ast)
+		if err != nil {
+			fmt.Printf("error: %s", err)
+			return
+		}
+		fmt.Printf("%s  (free variables %v)", syntactical_analyzer.UnparseDeBruijn(term), free)
+		return
+	}
+
+	if trace {
+		err = printTrace(syntacticalAnalyzer, ast, red, newStrategy)
+		if err != nil {
+			fmt.Printf("Error during comand: %v", err)
+		}
+		return
+	}
+
 	switch red {
 	case "alpha":
 		{
@@ -53,7 +261,7 @@ func main() {
 			if err != nil {
 				break
 			}
-			res, err := syntacticalAnalyzer.Unparse(ast)
+			res, err := unparse(ast)
 			if err != nil {
 				break
 			}
@@ -65,13 +273,55 @@ func main() {
 			if err != nil {
 				break
 			}
-			res, err := syntacticalAnalyzer.Unparse(ast)
+			res, err := unparse(ast)
 			if err != nil {
 				break
 			}
 			fmt.Printf("Unparsed after beta-reduction to %s", res)
 
 		}
+	case "delta":
+		{
+			ast, err = syntacticalAnalyzer.DeltaReduce(ast)
+			if err != nil {
+				break
+			}
+			res, err := unparse(ast)
+			if err != nil {
+				break
+			}
+			fmt.Printf("Unparsed after delta-reduction to %s", res)
+		}
+	case syntactical_analyzer.NORMAL_ORDER, syntactical_analyzer.APPLICATIVE_ORDER, syntactical_analyzer.CALL_BY_NAME,
+		syntactical_analyzer.CALL_BY_VALUE, syntactical_analyzer.CALL_BY_NEED, syntactical_analyzer.ETA, syntactical_analyzer.BETA_ETA:
+		{
+			var strategy syntactical_analyzer.ReductionStrategy
+			strategy, err = newStrategy(red)
+			if err != nil {
+				break
+			}
+			ast, err = syntacticalAnalyzer.Normalize(ctx, ast, syntactical_analyzer.NormalizationOptions{
+				Strategy: strategy,
+				MaxSteps: steps,
+				Timeout:  timeout,
+			})
+			if err != nil {
+				break
+			}
+			if decode != "" {
+				var value interface{}
+				if value, err = syntacticalAnalyzer.Decode(ast, shape); err != nil {
+					break
+				}
+				fmt.Printf("Decoded after %s reduction to %v", red, value)
+				break
+			}
+			res, err := unparse(ast)
+			if err != nil {
+				break
+			}
+			fmt.Printf("Unparsed after %s reduction to %s", red, res)
+		}
 	}
 
 	if err != nil {
@@ -80,6 +330,43 @@ func main() {
 	}
 }
 
+func printTrace(parser syntactical_analyzer.LL1PredictableParser, ast entity.Ast, red string,
+	newStrategy func(string) (syntactical_analyzer.ReductionStrategy, error)) error {
+	if red == "" {
+		red = syntactical_analyzer.NORMAL_ORDER
+	}
+	strategy, err := newStrategy(red)
+	if err != nil {
+		return err
+	}
+	steps, err := parser.Trace(ast, strategy)
+	for i, step := range steps {
+		if step.Redex == nil {
+			fmt.Printf("M%d = %s\n", i, step.Term)
+		} else {
+			fmt.Printf("  →%s M%d = %s  (at %s)\n", step.Redex.Rule, i, step.Term, step.Redex.Position)
+		}
+	}
+	return err
+}
+
+// printError renders diagnostics under the line of source they point to.
+func printError(err error, source string) {
+	var diagnostics entity.Diagnostics
+	if errors.As(err, &diagnostics) {
+		for _, diagnostic := range diagnostics {
+			fmt.Print(diagnostic.Render(source))
+		}
+		return
+	}
+	var diagnostic *entity.Diagnostic
+	if errors.As(err, &diagnostic) {
+		fmt.Print(diagnostic.Render(source))
+		return
+	}
+	fmt.Printf("error: %s", err)
+}
+
 func handleSubstitution(input string) map[string]string {
 	res := map[string]string{}
 	subs := strings.Split(input, ",")
@@ -6,12 +6,23 @@ import (
 	"github.com/DamirJann/pretty-trie/pkg/entity"
 )
 
+// Ast is a lambda term built from Var, Abs and App nodes, and Let nodes when they are kept.
 type Ast interface {
 	Visualize() string
-	Root() Node
+	Term() Term
 }
 
 type ast struct {
+	term Term
+}
+
+// ParseTree is the derivation of a lambda term in the LL(1) grammar.
+type ParseTree interface {
+	Visualize() string
+	Root() Node
+}
+
+type parseTree struct {
 	root Node
 }
 
@@ -24,10 +35,17 @@ type Node interface {
 	Delete(int)
 	Child() []Node
 	Replace(int, ...Node)
+	Span() Span
 }
 
-func NewAst(root Node) *ast {
+func NewAst(term Term) *ast {
 	return &ast{
+		term: term,
+	}
+}
+
+func NewParseTree(root Node) *parseTree {
+	return &parseTree{
 		root: root,
 	}
 }
@@ -65,6 +83,19 @@ func (n node) Token() *Token {
 	return n.token
 }
 
+// Span is the span of the token of a leaf or a terminal node, and the span covering the
+// children of a non-terminal one.
+func (n node) Span() Span {
+	if IsTerminal(n.token.Tag) || len(n.child) == 0 {
+		return n.token.Span
+	}
+	var res Span
+	for _, c := range n.child {
+		res = res.Join(c.Span())
+	}
+	return res
+}
+
 type node struct {
 	label string
 	token *Token
@@ -79,18 +110,67 @@ func NewNode(l string, t Token) Node {
 	}
 }
 
-func (a *ast) Root() Node {
-	return a.root
+func (a *ast) Term() Term {
+	return a.term
 }
 
 func (a *ast) Visualize() string {
 	idSeq := new(int)
 	*idSeq = 0
-	res, _ := drawing.Visualize(a.traverse(a.root, idSeq))
+	res, _ := drawing.Visualize(a.traverse(a.term, idSeq))
+	return res
+}
+
+func (a *ast) traverse(t Term, idSeq *int) (node []entity.Node, edge []entity.Edge) {
+	var label string
+	var child []Term
+	switch t := t.(type) {
+	case *Var:
+		label = t.Name
+	case *Abs:
+		label = fmt.Sprintf("λ%s", t.Param)
+		child = []Term{t.Body}
+	case *App:
+		label = "_"
+		child = []Term{t.Fun, t.Arg}
+	case *Let:
+		label = fmt.Sprintf("let %s", t.Name)
+		if t.Recursive {
+			label = fmt.Sprintf("letrec %s", t.Name)
+		}
+		child = []Term{t.Value, t.Body}
+	case *Error:
+		label = "error"
+	}
+	node = append(node, entity.Node{
+		Id:    *idSeq,
+		Label: label,
+	})
+	for _, c := range child {
+		edge = append(edge, entity.Edge{
+			From: node[0].Id,
+			To:   *idSeq + 1,
+		})
+		*idSeq++
+		newNode, newEdge := a.traverse(c, idSeq)
+		node = append(node, newNode...)
+		edge = append(edge, newEdge...)
+	}
+	return node, edge
+}
+
+func (p *parseTree) Root() Node {
+	return p.root
+}
+
+func (p *parseTree) Visualize() string {
+	idSeq := new(int)
+	*idSeq = 0
+	res, _ := drawing.Visualize(p.traverse(p.root, idSeq))
 	return res
 }
 
-func (a *ast) traverse(n Node, idSeq *int) (node []entity.Node, edge []entity.Edge) {
+func (p *parseTree) traverse(n Node, idSeq *int) (node []entity.Node, edge []entity.Edge) {
 	label := n.Label()
 	if label == "" {
 		label = fmt.Sprintf("%v", n.Token().Value)
@@ -105,7 +185,7 @@ func (a *ast) traverse(n Node, idSeq *int) (node []entity.Node, edge []entity.Ed
 			To:   *idSeq + 1,
 		})
 		*idSeq++
-		newNode, newEdge := a.traverse(child, idSeq)
+		newNode, newEdge := p.traverse(child, idSeq)
 		node = append(node, newNode...)
 		edge = append(edge, newEdge...)
 	}
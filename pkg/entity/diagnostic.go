@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type Severity int
+
+const (
+	ERROR Severity = iota
+	WARNING
+)
+
+func (s Severity) String() string {
+	if s == WARNING {
+		return "warning"
+	}
+	return "error"
+}
+
+// Code identifies the kind of a diagnostic: L codes come from the lexer, P codes from the parser
+// and T codes from the type checker.
+type Code string
+
+const (
+	UNEXPECTED_CHARACTER    Code = "L001"
+	UNTERMINATED_COMMENT    Code = "L002"
+	UNEXPECTED_TOKEN        Code = "P001"
+	UNEXPECTED_END_OF_INPUT Code = "P002"
+	UNDEFINED_NAME          Code = "P003"
+	CYCLIC_DEFINITION       Code = "P004"
+	NUMERAL_TOO_LARGE       Code = "P005"
+	UNBOUND_VARIABLE        Code = "T001"
+	MISSING_ANNOTATION      Code = "T002"
+	TYPE_MISMATCH           Code = "T003"
+	NOT_A_FUNCTION          Code = "T004"
+	RECURSIVE_BINDING       Code = "T005"
+	INFINITE_TYPE           Code = "T006"
+)
+
+// Diagnostic is an error found at Span of the input. For a syntax error, Expected is the set of
+// tokens the parser could accept there and Actual is the token it got instead.
+type Diagnostic struct {
+	Severity Severity
+	Code     Code
+	Span     Span
+	Message  string
+	Expected []Tag
+	Actual   *Token
+}
+
+func NewSyntaxDiagnostic(expected []Tag, actual Token) *Diagnostic {
+	code := UNEXPECTED_TOKEN
+	if actual.Tag == EPSILON {
+		code = UNEXPECTED_END_OF_INPUT
+	}
+	return &Diagnostic{
+		Severity: ERROR,
+		Code:     code,
+		Span:     actual.Span,
+		Message:  fmt.Sprintf("expected %s, found %s", describeTags(expected), describeToken(actual)),
+		Expected: expected,
+		Actual:   &actual,
+	}
+}
+
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%v: %v[%s]: %s", d.Span.Start, d.Severity, d.Code, d.Message)
+}
+
+// Diagnostics are the diagnostics of one input, in the order they were found.
+type Diagnostics []*Diagnostic
+
+func (d Diagnostics) Error() string {
+	lines := make([]string, len(d))
+	for i, diagnostic := range d {
+		lines[i] = diagnostic.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Render prints the diagnostic followed by the line of source it points to, with the span
+// underlined by carets. A span running over several lines is underlined up to the end of its first line.
+//
+//	error[P002]: expected _ or ), found end of input
+//	 --> 1:8
+//	  |
+//	1 | λx.(x_y
+//	  |        ^
+func (d *Diagnostic) Render(source string) string {
+	line := d.Span.Start.Line
+	lines := strings.Split(source, "\n")
+	if line < 1 || line > len(lines) {
+		return fmt.Sprintf("%v[%s]: %s\n --> %v\n", d.Severity, d.Code, d.Message, d.Span.Start)
+	}
+	text := strings.TrimRight(lines[line-1], "\r")
+
+	var underline strings.Builder
+	column := 1
+	for _, r := range text {
+		if column == d.Span.Start.Column {
+			break
+		}
+		if r == '\t' {
+			underline.WriteRune('\t')
+		} else {
+			underline.WriteRune(' ')
+		}
+		column++
+	}
+	width := 1
+	if d.Span.End.Line == line && d.Span.End.Column > d.Span.Start.Column {
+		width = d.Span.End.Column - d.Span.Start.Column
+	} else if d.Span.End.Line > line && utf8.RuneCountInString(text) >= d.Span.Start.Column {
+		width = utf8.RuneCountInString(text) - d.Span.Start.Column + 1
+	}
+	underline.WriteString(strings.Repeat("^", width))
+
+	gutter := strings.Repeat(" ", len(fmt.Sprint(line)))
+	return fmt.Sprintf("%v[%s]: %s\n%s--> %v\n%s |\n%d | %s\n%s | %s\n",
+		d.Severity, d.Code, d.Message, gutter, d.Span.Start, gutter, line, text, gutter, underline.String())
+}
+
+func describeToken(t Token) string {
+	if t.Tag == VARIABLE {
+		return fmt.Sprintf("variable %v", t.Value)
+	}
+	return describeTag(t.Tag)
+}
+
+func describeTag(t Tag) string {
+	if t == EPSILON {
+		return "end of input"
+	}
+	return t.String()
+}
+
+// describeTags joins the names of tags as in "a, b or c".
+func describeTags(tags []Tag) string {
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = describeTag(t)
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
@@ -0,0 +1,47 @@
+package entity
+
+import "fmt"
+
+// Location is a point of the source text. Offset counts bytes from the beginning of the
+// input, Line and Column count from 1 and columns are measured in runes.
+type Location struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// Span is the source range [Start, End) a token or a node was read from. The zero Span
+// belongs to nodes that have no source, like the ones built by a reduction.
+type Span struct {
+	Start Location
+	End   Location
+}
+
+func (s Span) IsZero() bool {
+	return s == Span{}
+}
+
+// Join returns the smallest span covering both s and o. A zero span is ignored.
+func (s Span) Join(o Span) Span {
+	if s.IsZero() {
+		return o
+	}
+	if o.IsZero() {
+		return s
+	}
+	if o.Start.Offset < s.Start.Offset {
+		s.Start = o.Start
+	}
+	if o.End.Offset > s.End.Offset {
+		s.End = o.End
+	}
+	return s
+}
+
+func (l Location) String() string {
+	return fmt.Sprintf("%d:%d", l.Line, l.Column)
+}
+
+func (s Span) String() string {
+	return fmt.Sprintf("%v-%v", s.Start, s.End)
+}
@@ -0,0 +1,105 @@
+package entity
+
+// Term is a node of the semantic tree of a lambda term: a variable, an abstraction, an application
+// or a let binding.
+// Terms are never modified once built, so a sub-term can be shared between several terms.
+//
+// Span tells where a term was read from. A term rewritten from another one keeps the span of the
+// original node, so that the result of a reduction can still be traced back to the source.
+type Term interface {
+	isTerm()
+}
+
+type Var struct {
+	Name string
+	Span Span
+}
+
+// Abs is the abstraction λParam.Body, or λParam:Type.Body when its parameter is annotated with
+// a type, Type being nil otherwise. Only the type checker reads the annotation.
+type Abs struct {
+	Param string
+	Type  Type
+	Body  Term
+	Span  Span
+}
+
+type App struct {
+	Fun  Term
+	Arg  Term
+	Span Span
+}
+
+// Let binds Name to Value in Body, and in Value too when the binding is Recursive (letrec).
+// The parser expands let bindings to redexes unless asked to keep them, and the reductions
+// only accept expanded terms.
+type Let struct {
+	Name      string
+	Value     Term
+	Body      Term
+	Recursive bool
+	Span      Span
+}
+
+// Error stands for a part of the input the parser could not make sense of. It only appears in
+// the partial trees returned together with syntax errors, and no reduction accepts it.
+type Error struct {
+	Span Span
+}
+
+func (*Var) isTerm()   {}
+func (*Abs) isTerm()   {}
+func (*App) isTerm()   {}
+func (*Let) isTerm()   {}
+func (*Error) isTerm() {}
+
+func NewVar(name string) *Var {
+	return &Var{Name: name}
+}
+
+func NewAbs(param string, body Term) *Abs {
+	return &Abs{Param: param, Body: body}
+}
+
+func NewApp(fun Term, arg Term) *App {
+	return &App{Fun: fun, Arg: arg}
+}
+
+// Rename returns a variable named name read from the same place as v.
+func (v *Var) Rename(name string) *Var {
+	return &Var{Name: name, Span: v.Span}
+}
+
+// Rebuild returns an abstraction with the given parameter and body read from the same place as a,
+// with the same annotation.
+func (a *Abs) Rebuild(param string, body Term) *Abs {
+	return &Abs{Param: param, Type: a.Type, Body: body, Span: a.Span}
+}
+
+// Rebuild returns an application of fun to arg read from the same place as a.
+func (a *App) Rebuild(fun Term, arg Term) *App {
+	return &App{Fun: fun, Arg: arg, Span: a.Span}
+}
+
+// Rebuild returns a binding of the same name to value in body read from the same place as l.
+func (l *Let) Rebuild(value Term, body Term) *Let {
+	return &Let{Name: l.Name, Value: value, Body: body, Recursive: l.Recursive, Span: l.Span}
+}
+
+// SpanOf returns the span of t, or the zero Span if t is nil.
+func SpanOf(t Term) Span {
+	switch t := t.(type) {
+	case *Var:
+		return t.Span
+	case *Abs:
+		return t.Span
+	case *App:
+		return t.Span
+	case *Let:
+		return t.Span
+	case *Error:
+		return t.Span
+	default:
+		return Span{}
+	}
+}
@@ -1,5 +1,7 @@
 package entity
 
+import "fmt"
+
 type Tag int
 
 const (
@@ -10,13 +12,75 @@ const (
 	LAMBDA
 	LEFT_BRACKET
 	RIGHT_BRACKET
+	LET
+	LETREC
+	IN
+	EQUALS
+	COLON
+	ARROW
 
 	// Non-Terminals
 	TERM
 	TERMS
+	PARAMETERS
+	DEFINITION
+	ANNOTATION
+	TYPE
+	TYPES
 	EPSILON
+	// INVALID marks the tokens skipped, or the token missing, where the parser recovered from a syntax error.
+	INVALID
 )
 
+func (t Tag) String() string {
+	switch t {
+	case ABSTRACTION:
+		return "."
+	case APPLICATION:
+		return "_"
+	case VARIABLE:
+		return "variable"
+	case LAMBDA:
+		return "λ"
+	case LEFT_BRACKET:
+		return "("
+	case RIGHT_BRACKET:
+		return ")"
+	case LET:
+		return "let"
+	case LETREC:
+		return "letrec"
+	case IN:
+		return "in"
+	case EQUALS:
+		return "="
+	case COLON:
+		return ":"
+	case ARROW:
+		return "→"
+	case TERM:
+		return "Λ"
+	case TERMS:
+		return "Λs"
+	case PARAMETERS:
+		return "vs"
+	case DEFINITION:
+		return "D"
+	case ANNOTATION:
+		return "a"
+	case TYPE:
+		return "τ"
+	case TYPES:
+		return "τs"
+	case EPSILON:
+		return "ε"
+	case INVALID:
+		return "error"
+	default:
+		return fmt.Sprintf("Tag(%d)", int(t))
+	}
+}
+
 func IsTerminal(t Tag) bool {
 	return map[Tag]bool{
 		ABSTRACTION:   true,
@@ -25,6 +89,12 @@ func IsTerminal(t Tag) bool {
 		LAMBDA:        true,
 		LEFT_BRACKET:  true,
 		RIGHT_BRACKET: true,
+		LET:           true,
+		LETREC:        true,
+		IN:            true,
+		EQUALS:        true,
+		COLON:         true,
+		ARROW:         true,
 	}[t]
 }
 
@@ -38,6 +108,15 @@ func NewEpsilonToken() *Token {
 type Token struct {
 	Tag   Tag
 	Value interface{}
+	Span  Span
+}
+
+func NewErrorToken(span Span) *Token {
+	return &Token{
+		Tag:   INVALID,
+		Value: "error",
+		Span:  span,
+	}
 }
 
 func NewLambdaToken(lexem string) *Token {
@@ -74,6 +153,43 @@ func NewBracketToken(lexem string) *Token {
 	}
 }
 
+func NewEqualsToken(lexem string) *Token {
+	return &Token{
+		Tag:   EQUALS,
+		Value: lexem,
+	}
+}
+
+func NewColonToken(lexem string) *Token {
+	return &Token{
+		Tag:   COLON,
+		Value: lexem,
+	}
+}
+
+func NewArrowToken(lexem string) *Token {
+	return &Token{
+		Tag:   ARROW,
+		Value: lexem,
+	}
+}
+
+// NewKeywordToken returns the token of let, letrec or in, and nil for any other lexem.
+func NewKeywordToken(lexem string) *Token {
+	tag, ok := map[string]Tag{
+		"let":    LET,
+		"letrec": LETREC,
+		"in":     IN,
+	}[lexem]
+	if !ok {
+		return nil
+	}
+	return &Token{
+		Tag:   tag,
+		Value: lexem,
+	}
+}
+
 func NewVariableToken(lexem string) *Token {
 	return &Token{
 		Tag:   VARIABLE,
@@ -0,0 +1,78 @@
+package entity
+
+// Type is a simple type: a base type, a type variable or a function type. Like terms, types are
+// never modified once built and carry the span they were read from, zero for the types built by a
+// type checker.
+type Type interface {
+	isType()
+	String() string
+}
+
+type BaseType struct {
+	Name string
+	Span Span
+}
+
+// TypeVariable stands for any type. Only type inference builds type variables, the annotations of
+// a term are read as base types.
+type TypeVariable struct {
+	Name string
+	Span Span
+}
+
+// Arrow is the type From → To of the functions from From to To.
+type Arrow struct {
+	From Type
+	To   Type
+	Span Span
+}
+
+func (*BaseType) isType()     {}
+func (*TypeVariable) isType() {}
+func (*Arrow) isType()        {}
+
+func NewBaseType(name string) *BaseType {
+	return &BaseType{Name: name}
+}
+
+func NewTypeVariable(name string) *TypeVariable {
+	return &TypeVariable{Name: name}
+}
+
+func NewArrow(from Type, to Type) *Arrow {
+	return &Arrow{From: from, To: to}
+}
+
+func (t *BaseType) String() string {
+	return t.Name
+}
+
+func (t *TypeVariable) String() string {
+	return t.Name
+}
+
+// String prints the arrow with only the brackets it needs, → associating to the right.
+func (t *Arrow) String() string {
+	from := t.From.String()
+	if _, ok := t.From.(*Arrow); ok {
+		from = "(" + from + ")"
+	}
+	return from + " → " + t.To.String()
+}
+
+// EqualTypes tells whether a and b are the same type, wherever they were read from.
+func EqualTypes(a Type, b Type) bool {
+	switch a := a.(type) {
+	case *BaseType:
+		b, ok := b.(*BaseType)
+		return ok && a.Name == b.Name
+	case *TypeVariable:
+		b, ok := b.(*TypeVariable)
+		return ok && a.Name == b.Name
+	case *Arrow:
+		b, ok := b.(*Arrow)
+		return ok && EqualTypes(a.From, b.From) && EqualTypes(a.To, b.To)
+	default:
+		return false
+	}
+}
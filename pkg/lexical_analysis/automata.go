@@ -2,14 +2,52 @@ package lexical_analysis
 
 import (
 	"bytes"
-	"errors"
+	"fmt"
 	"io"
 	"math-parser/pkg/entity"
+	"unicode"
+	"unicode/utf8"
 )
 
+// IdentifierPolicy tells which runes make up a variable name. A name starts with a letter, unless
+// it is a numeral.
+type IdentifierPolicy struct {
+	// SingleLetter ends a name before the next letter, so that xy lexes as the two variables x and y.
+	SingleLetter bool
+	// Digits, Primes and Underscores allow 0-9, ' and _ after the first letter. An underscore
+	// belongs to the name only when a letter or a digit follows it, otherwise it is an application.
+	Digits      bool
+	Primes      bool
+	Underscores bool
+	// Numerals reads a sequence of digits as a name of its own, like 42, which the prelude
+	// defines as a Church numeral.
+	Numerals bool
+}
+
+type Config struct {
+	Identifiers IdentifierPolicy
+	// Primitives reads integer literals, like 42 or -7, and the operators +, -, * and == as
+	// variables of their own, the primitives of the reduction strategies with δ-rules. A - directly
+	// followed by a digit starts a negative literal, and -- still starts a comment.
+	Primitives bool
+}
+
+// DefaultConfig lexes multi-character names made of letters, digits and primes, like foo, x1 or x'.
+func DefaultConfig() Config {
+	return Config{
+		Identifiers: IdentifierPolicy{
+			Digits: true,
+			Primes: true,
+		},
+	}
+}
+
 type automata struct {
-	input *bytes.Buffer
-	lexem string
+	config   Config
+	input    *bytes.Buffer
+	lexem    string
+	location entity.Location
+	start    entity.Location
 }
 
 type Automata interface {
@@ -17,14 +55,34 @@ type Automata interface {
 }
 
 func NewAutomata() Automata {
-	return &automata{}
+	return NewConfiguredAutomata(DefaultConfig())
+}
+
+func NewConfiguredAutomata(config Config) Automata {
+	return &automata{
+		config: config,
+	}
 }
 
 func (a *automata) Peek() (rune, error) {
-	r, _, err := a.input.ReadRune()
+	r, size, err := a.input.ReadRune()
+	if err == nil {
+		a.advance(r, size)
+	}
 	return r, err
 }
 
+// advance moves the location past the rune r of size bytes.
+func (a *automata) advance(r rune, size int) {
+	a.location.Offset += size
+	if r == NEW_LINE {
+		a.location.Line++
+		a.location.Column = 1
+	} else {
+		a.location.Column++
+	}
+}
+
 func (a *automata) Lookahead() (rune, error) {
 	b, _, err := a.input.ReadRune()
 	if err == nil {
@@ -36,14 +94,35 @@ func (a *automata) Lookahead() (rune, error) {
 	return b, err
 }
 
+// LookaheadAt returns the rune i positions after the next one without reading anything.
+func (a *automata) LookaheadAt(i int) rune {
+	rest := a.input.Bytes()
+	for ; i > 0 && len(rest) > 0; i-- {
+		_, size := utf8.DecodeRune(rest)
+		rest = rest[size:]
+	}
+	if len(rest) == 0 {
+		return EOF
+	}
+	r, _ := utf8.DecodeRune(rest)
+	return r
+}
+
 func (a *automata) Unread() error {
 	return a.input.UnreadRune()
 }
 
 func (a *automata) extractToken(input *bytes.Buffer) (*entity.Token, error) {
+	if a.input != input {
+		a.location = entity.Location{Line: 1, Column: 1}
+	}
 	a.input = input
 	a.lexem = ""
 
+	if err := a.s0(); err != nil {
+		return nil, err
+	}
+
 	lookahead, err := a.Lookahead()
 	if lookahead == EOF {
 		return nil, io.EOF
@@ -52,7 +131,82 @@ func (a *automata) extractToken(input *bytes.Buffer) (*entity.Token, error) {
 		return nil, err
 	}
 
-	return a.s1()
+	a.start = a.location
+	token, err := a.s1()
+	if err != nil {
+		return nil, err
+	}
+	token.Span = entity.Span{Start: a.start, End: a.location}
+	return token, nil
+}
+
+// s0 skips whitespace and comments before a token.
+func (a *automata) s0() error {
+	for {
+		lookahead, next := a.LookaheadAt(0), a.LookaheadAt(1)
+		switch {
+		case lookahead != EOF && unicode.IsSpace(lookahead):
+			if _, err := a.Peek(); err != nil {
+				return err
+			}
+		case lookahead == HASH || lookahead == DASH && next == DASH:
+			if err := a.skipLineComment(); err != nil {
+				return err
+			}
+		case lookahead == LEFT_BRACE && next == DASH:
+			if err := a.skipBlockComment(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+func (a *automata) skipLineComment() error {
+	for {
+		if lookahead := a.LookaheadAt(0); lookahead == EOF || lookahead == NEW_LINE {
+			return nil
+		}
+		if _, err := a.Peek(); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *automata) skipBlockComment() error {
+	start := a.location
+	depth := 0
+	for {
+		lookahead, next := a.LookaheadAt(0), a.LookaheadAt(1)
+		switch {
+		case lookahead == EOF:
+			return &entity.Diagnostic{
+				Severity: entity.ERROR,
+				Code:     entity.UNTERMINATED_COMMENT,
+				Span:     entity.Span{Start: start, End: a.location},
+				Message:  "unterminated block comment",
+			}
+		case lookahead == LEFT_BRACE && next == DASH:
+			depth++
+		case lookahead == DASH && next == RIGHT_BRACE:
+			depth--
+		default:
+			if _, err := a.Peek(); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := a.Peek(); err != nil {
+			return err
+		}
+		if _, err := a.Peek(); err != nil {
+			return err
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
 }
 
 func (a *automata) s1() (*entity.Token, error) {
@@ -66,7 +220,12 @@ func (a *automata) s1() (*entity.Token, error) {
 		a.lexem += string(peek)
 		return nextState()
 	} else {
-		return nil, errors.New("error in S1 state")
+		return nil, &entity.Diagnostic{
+			Severity: entity.ERROR,
+			Code:     entity.UNEXPECTED_CHARACTER,
+			Span:     entity.Span{Start: a.start, End: a.location},
+			Message:  fmt.Sprintf("unexpected character %q", peek),
+		}
 	}
 
 }
@@ -83,6 +242,16 @@ func (a *automata) s4() (*entity.Token, error) {
 }
 
 func (a *automata) s5() (*entity.Token, error) {
+	for a.continuesIdentifier(a.LookaheadAt(0), a.LookaheadAt(1)) {
+		peek, err := a.Peek()
+		if err != nil {
+			return nil, err
+		}
+		a.lexem += string(peek)
+	}
+	if keyword := entity.NewKeywordToken(a.lexem); keyword != nil {
+		return keyword, nil
+	}
 	return entity.NewVariableToken(a.lexem), nil
 }
 
@@ -90,20 +259,105 @@ func (a *automata) s6() (*entity.Token, error) {
 	return entity.NewBracketToken(a.lexem), nil
 }
 
+func (a *automata) s7() (*entity.Token, error) {
+	return entity.NewEqualsToken(a.lexem), nil
+}
+
+func (a *automata) s8() (*entity.Token, error) {
+	for unicode.IsDigit(a.LookaheadAt(0)) {
+		peek, err := a.Peek()
+		if err != nil {
+			return nil, err
+		}
+		a.lexem += string(peek)
+	}
+	return entity.NewVariableToken(a.lexem), nil
+}
+
+// s9 reads an operator, or a negative literal when a digit follows -.
+func (a *automata) s9() (*entity.Token, error) {
+	switch a.lexem {
+	case string(DASH):
+		if unicode.IsDigit(a.LookaheadAt(0)) {
+			return a.s8()
+		}
+	case string(EQUALS):
+		peek, err := a.Peek()
+		if err != nil {
+			return nil, err
+		}
+		a.lexem += string(peek)
+	}
+	return entity.NewVariableToken(a.lexem), nil
+}
+
+func (a *automata) s10() (*entity.Token, error) {
+	return entity.NewColonToken(a.lexem), nil
+}
+
+// s11 reads → or ->.
+func (a *automata) s11() (*entity.Token, error) {
+	if a.lexem == string(DASH) {
+		peek, err := a.Peek()
+		if err != nil {
+			return nil, err
+		}
+		a.lexem += string(peek)
+	}
+	return entity.NewArrowToken(a.lexem), nil
+}
+
 func (a *automata) s1TransitTo(lookahead rune) func() (*entity.Token, error) {
+	if lookahead == DASH && a.LookaheadAt(0) == GREATER {
+		return a.s11
+	}
+	if a.config.Primitives {
+		if lookahead == PLUS || lookahead == DASH || lookahead == STAR || lookahead == EQUALS && a.LookaheadAt(0) == EQUALS {
+			return a.s9
+		}
+	}
 	res, ok := map[rune]func() (*entity.Token, error){
 		ABSTRACTION:   a.s2,
 		APPLICATION:   a.s3,
 		LAMBDA:        a.s4,
+		BACKSLASH:     a.s4,
+		CARET:         a.s4,
 		LEFT_BRACKET:  a.s6,
 		RIGHT_BRACKET: a.s6,
+		EQUALS:        a.s7,
+		COLON:         a.s10,
+		ARROW:         a.s11,
 	}[lookahead]
 	if ok {
 		return res
 	} else {
-		if lookahead >= 'a' && lookahead <= 'z' {
+		if isLetter(lookahead) {
 			return a.s5
 		}
+		if unicode.IsDigit(lookahead) && (a.config.Identifiers.Numerals || a.config.Primitives) {
+			return a.s8
+		}
 	}
 	return nil
 }
+
+// continuesIdentifier tells whether r, followed by next, belongs to the name being read.
+func (a *automata) continuesIdentifier(r rune, next rune) bool {
+	policy := a.config.Identifiers
+	switch {
+	case isLetter(r):
+		return !policy.SingleLetter
+	case unicode.IsDigit(r):
+		return policy.Digits
+	case r == PRIME:
+		return policy.Primes
+	case r == APPLICATION:
+		return policy.Underscores && (isLetter(next) || unicode.IsDigit(next))
+	default:
+		return false
+	}
+}
+
+func isLetter(r rune) bool {
+	return unicode.IsLetter(r) && r != LAMBDA
+}
@@ -17,6 +17,58 @@ func TestLexicalAnalyzer_Tokenize(t *testing.T) {
 			name:     "Happy flow. Process with basic operations",
 			scenario: happyFlowTokenizeWithBasicOperations,
 		},
+		{
+			name:     "Happy flow. Process multi-character identifiers",
+			scenario: happyFlowTokenizeMultiCharacterIdentifiers,
+		},
+		{
+			name:     "Happy flow. Process identifiers with underscores",
+			scenario: happyFlowTokenizeIdentifiersWithUnderscores,
+		},
+		{
+			name:     "Happy flow. Process single-letter identifiers with primes",
+			scenario: happyFlowTokenizeSingleLetterIdentifiersWithPrimes,
+		},
+		{
+			name:     "Negative flow. Process identifier starting with digit",
+			scenario: negativeFlowTokenizeIdentifierStartingWithDigit,
+		},
+		{
+			name:     "Happy flow. Process whitespace and line comments",
+			scenario: happyFlowTokenizeWhitespaceAndLineComments,
+		},
+		{
+			name:     "Happy flow. Process nested block comments",
+			scenario: happyFlowTokenizeNestedBlockComments,
+		},
+		{
+			name:     "Happy flow. Process ASCII lambdas",
+			scenario: happyFlowTokenizeAsciiLambdas,
+		},
+		{
+			name:     "Happy flow. Process let keywords",
+			scenario: happyFlowTokenizeLetKeywords,
+		},
+		{
+			name:     "Happy flow. Process numerals",
+			scenario: happyFlowTokenizeNumerals,
+		},
+		{
+			name:     "Happy flow. Process primitives",
+			scenario: happyFlowTokenizePrimitives,
+		},
+		{
+			name:     "Happy flow. Process type annotations",
+			scenario: happyFlowTokenizeTypeAnnotations,
+		},
+		{
+			name:     "Happy flow. Process token positions",
+			scenario: happyFlowTokenizePositions,
+		},
+		{
+			name:     "Negative flow. Process unterminated block comment",
+			scenario: negativeFlowTokenizeUnterminatedBlockComment,
+		},
 	}
 
 	t.Parallel()
@@ -28,7 +80,9 @@ func TestLexicalAnalyzer_Tokenize(t *testing.T) {
 func happyFlowTokenizeWithBasicOperations(t *testing.T) {
 	// arrange
 	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
-	automata := NewAutomata()
+	config := DefaultConfig()
+	config.Identifiers.SingleLetter = true
+	automata := NewConfiguredAutomata(config)
 	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
 	expression := "(λf.(λx.f(xx))_(λx.f(xx)))"
 
@@ -65,3 +119,280 @@ func happyFlowTokenizeWithBasicOperations(t *testing.T) {
 	assert.Equal(t, ts[24].Tag, entity.RIGHT_BRACKET)
 	assert.Equal(t, ts[25].Tag, entity.RIGHT_BRACKET)
 }
+
+func happyFlowTokenizeMultiCharacterIdentifiers(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := NewAutomata()
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "λsucc.succ_x1_x'_Xy_αβ"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 12)
+	assert.Equal(t, ts[1].Value, "succ")
+	assert.Equal(t, ts[3].Value, "succ")
+	assert.Equal(t, ts[4].Tag, entity.APPLICATION)
+	assert.Equal(t, ts[5].Value, "x1")
+	assert.Equal(t, ts[7].Value, "x'")
+	assert.Equal(t, ts[9].Value, "Xy")
+	assert.Equal(t, ts[11].Tag, entity.VARIABLE)
+	assert.Equal(t, ts[11].Value, "αβ")
+}
+
+func happyFlowTokenizeIdentifiersWithUnderscores(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := DefaultConfig()
+	config.Identifiers.Underscores = true
+	automata := NewConfiguredAutomata(config)
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "is_zero_(n_)_m"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 8)
+	assert.Equal(t, ts[0].Value, "is_zero")
+	assert.Equal(t, ts[1].Tag, entity.APPLICATION)
+	assert.Equal(t, ts[3].Value, "n")
+	assert.Equal(t, ts[4].Tag, entity.APPLICATION)
+	assert.Equal(t, ts[7].Value, "m")
+}
+
+func happyFlowTokenizeSingleLetterIdentifiersWithPrimes(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := DefaultConfig()
+	config.Identifiers.SingleLetter = true
+	automata := NewConfiguredAutomata(config)
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "x'y2z"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 3)
+	assert.Equal(t, ts[0].Value, "x'")
+	assert.Equal(t, ts[1].Value, "y2")
+	assert.Equal(t, ts[2].Value, "z")
+}
+
+func negativeFlowTokenizeIdentifierStartingWithDigit(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := NewAutomata()
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "x_1x"
+
+	// act
+	_, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	diagnostic, ok := err.(*entity.Diagnostic)
+	assert.Assert(t, ok)
+	assert.Equal(t, diagnostic.Code, entity.UNEXPECTED_CHARACTER)
+	assert.Equal(t, diagnostic.Span.String(), "1:3-1:4")
+	assert.Equal(t, err.Error(), "1:3: error[L001]: unexpected character '1'")
+}
+
+func happyFlowTokenizeWhitespaceAndLineComments(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := NewAutomata()
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "# identity\n  λx .  -- binder\n\tx _ y -- applied\n"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 6)
+	assert.Equal(t, ts[0].Tag, entity.LAMBDA)
+	assert.Equal(t, ts[1].Value, "x")
+	assert.Equal(t, ts[2].Tag, entity.ABSTRACTION)
+	assert.Equal(t, ts[3].Value, "x")
+	assert.Equal(t, ts[4].Tag, entity.APPLICATION)
+	assert.Equal(t, ts[5].Value, "y")
+}
+
+func happyFlowTokenizeNestedBlockComments(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := NewAutomata()
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "f {- outer {- inner -} still outer -}_{--}x"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 3)
+	assert.Equal(t, ts[0].Value, "f")
+	assert.Equal(t, ts[1].Tag, entity.APPLICATION)
+	assert.Equal(t, ts[2].Value, "x")
+}
+
+func negativeFlowTokenizeUnterminatedBlockComment(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := NewAutomata()
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "x {- {- -}"
+
+	// act
+	_, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.ErrorContains(t, err, "unterminated block comment")
+}
+
+func happyFlowTokenizePositions(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := NewAutomata()
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "λfoo.\n\tfoo_y"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+	again, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 6)
+	assert.Equal(t, ts[0].Span, entity.Span{
+		Start: entity.Location{Offset: 0, Line: 1, Column: 1},
+		End:   entity.Location{Offset: 2, Line: 1, Column: 2},
+	})
+	assert.Equal(t, ts[1].Span, entity.Span{
+		Start: entity.Location{Offset: 2, Line: 1, Column: 2},
+		End:   entity.Location{Offset: 5, Line: 1, Column: 5},
+	})
+	assert.Equal(t, ts[3].Span, entity.Span{
+		Start: entity.Location{Offset: 8, Line: 2, Column: 2},
+		End:   entity.Location{Offset: 11, Line: 2, Column: 5},
+	})
+	assert.Equal(t, ts[5].Span.String(), "2:6-2:7")
+	assert.Equal(t, again[0].Span, ts[0].Span)
+}
+
+func happyFlowTokenizeAsciiLambdas(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := NewAutomata()
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := `\x.^y.x`
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 7)
+	assert.Equal(t, ts[0].Tag, entity.LAMBDA)
+	assert.Equal(t, ts[0].Value, `\`)
+	assert.Equal(t, ts[3].Tag, entity.LAMBDA)
+	assert.Equal(t, ts[3].Value, "^")
+}
+
+func happyFlowTokenizeLetKeywords(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := NewAutomata()
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "letrec f=λx.x in let lets = f in inner"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 14)
+	assert.Equal(t, ts[0].Tag, entity.LETREC)
+	assert.Equal(t, ts[2].Tag, entity.EQUALS)
+	assert.Equal(t, ts[7].Tag, entity.IN)
+	assert.Equal(t, ts[8].Tag, entity.LET)
+	assert.Equal(t, ts[9].Tag, entity.VARIABLE)
+	assert.Equal(t, ts[9].Value, "lets")
+	assert.Equal(t, ts[12].Tag, entity.IN)
+	assert.Equal(t, ts[13].Value, "inner")
+}
+
+func happyFlowTokenizeNumerals(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := DefaultConfig()
+	config.Identifiers.Numerals = true
+	automata := NewConfiguredAutomata(config)
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "plus 12 x3"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 3)
+	assert.Equal(t, ts[1].Tag, entity.VARIABLE)
+	assert.Equal(t, ts[1].Value, "12")
+	assert.Equal(t, ts[1].Span.String(), "1:6-1:8")
+	assert.Equal(t, ts[2].Value, "x3")
+}
+
+func happyFlowTokenizePrimitives(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := DefaultConfig()
+	config.Primitives = true
+	automata := NewConfiguredAutomata(config)
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "if (== x -12) (+ 1 2) (*_3_(- 4 5)) -- comment"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+	var values []interface{}
+	for _, token := range ts {
+		if token.Tag == entity.VARIABLE {
+			values = append(values, token.Value)
+		}
+	}
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.DeepEqual(t, values, []interface{}{"if", "==", "x", "-12", "+", "1", "2", "*", "3", "-", "4", "5"})
+	assert.Equal(t, ts[4].Span.String(), "1:10-1:13")
+}
+
+func happyFlowTokenizeTypeAnnotations(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := DefaultConfig()
+	config.Primitives = true
+	automata := NewConfiguredAutomata(config)
+	lexicalAnalyzer := NewLexicalAnalyzer(ctx, automata)
+	expression := "λf:A->B x:A→B.- 1 2"
+
+	// act
+	ts, err := lexicalAnalyzer.Tokenize(expression)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ts), 15)
+	assert.Equal(t, ts[2].Tag, entity.COLON)
+	assert.Equal(t, ts[4].Tag, entity.ARROW)
+	assert.Equal(t, ts[4].Value, "->")
+	assert.Equal(t, ts[4].Span.String(), "1:5-1:7")
+	assert.Equal(t, ts[9].Tag, entity.ARROW)
+	assert.Equal(t, ts[9].Value, "→")
+	assert.Equal(t, ts[12].Value, "-")
+}
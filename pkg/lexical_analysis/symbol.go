@@ -4,9 +4,32 @@ const (
 	LAMBDA      = rune('λ')
 	APPLICATION = rune('_')
 	ABSTRACTION = rune('.')
+	PRIME       = rune('\'')
+	EQUALS      = rune('=')
+
+	// COLON annotates a parameter with its type, in which ARROW, or its ASCII alternative ->, is the
+	// type of functions.
+	COLON   = rune(':')
+	ARROW   = rune('→')
+	GREATER = rune('>')
+
+	// PLUS and STAR are operators, together with DASH and a double EQUALS, when primitives are read.
+	PLUS = rune('+')
+	STAR = rune('*')
+
+	// BACKSLASH and CARET are ASCII alternatives for λ.
+	BACKSLASH = rune('\\')
+	CARET     = rune('^')
 
 	LEFT_BRACKET  = rune('(')
 	RIGHT_BRACKET = rune(')')
 
+	// Comments are -- … and # … up to the end of the line, or {- … -}, which may be nested.
+	HASH        = rune('#')
+	DASH        = rune('-')
+	LEFT_BRACE  = rune('{')
+	RIGHT_BRACE = rune('}')
+	NEW_LINE    = rune('\n')
+
 	EOF = 0
 )
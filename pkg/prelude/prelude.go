@@ -0,0 +1,115 @@
+package prelude
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	syntactical_analyzer "math-parser/pkg/syntactical_analysis"
+	"strconv"
+	"strings"
+)
+
+//go:embed prelude.lambda
+var source string
+
+// MAX_NUMERAL is the largest name made of digits the prelude defines: a Church numeral has as many
+// applications as its value, so a larger one would take more memory than a reduction may use anyway.
+const MAX_NUMERAL = 10000
+
+// Prelude is the standard library of Church encodings, read from prelude.lambda. Its names are
+// used like those of a definitions file, and a name made of digits, like 42, stands for the
+// Church numeral λf x.f (… (f x)) with as many f, up to MAX_NUMERAL.
+type Prelude struct {
+	definitions syntactical_analyzer.Definitions
+}
+
+// Load tokenizes and parses the prelude, which is written with juxtaposition.
+func Load(ctx context.Context) (*Prelude, error) {
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, lexical_analysis.NewAutomata())
+	parser := syntactical_analyzer.NewConfiguredLL1PredictableParser(ctx, syntactical_analyzer.Config{Juxtaposition: true})
+
+	tk, err := analyzer.Tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	definitions, err := parser.ParseDefinitions(tk)
+	if err != nil {
+		return nil, err
+	}
+	return &Prelude{definitions: definitions}, nil
+}
+
+// Lookup returns the term defined as name, as written in the prelude.
+func (p *Prelude) Lookup(name string) (entity.Ast, bool) {
+	for i := len(p.definitions) - 1; i >= 0; i-- {
+		if p.definitions[i].Name == name {
+			return entity.NewAst(p.definitions[i].Term), true
+		}
+	}
+	if n, err := strconv.Atoi(name); err == nil && n <= MAX_NUMERAL {
+		return entity.NewAst(numeral(n)), true
+	}
+	return nil, false
+}
+
+// Definitions returns the definitions of the prelude, preceded by the numerals terms refer to. A
+// numeral above MAX_NUMERAL gives an entity.Diagnostic.
+func (p *Prelude) Definitions(terms ...entity.Term) (syntactical_analyzer.Definitions, error) {
+	var res syntactical_analyzer.Definitions
+	var diagnostics entity.Diagnostics
+	seen := map[string]bool{}
+	for _, t := range terms {
+		for _, v := range numerals(t) {
+			n, err := strconv.Atoi(v.Name)
+			if err != nil || n > MAX_NUMERAL {
+				diagnostics = append(diagnostics, &entity.Diagnostic{
+					Severity: entity.ERROR,
+					Code:     entity.NUMERAL_TOO_LARGE,
+					Span:     v.Span,
+					Message:  fmt.Sprintf("numeral %s is larger than %d", v.Name, MAX_NUMERAL),
+				})
+			} else if !seen[v.Name] {
+				seen[v.Name] = true
+				res = append(res, syntactical_analyzer.Definition{Name: v.Name, Term: numeral(n)})
+			}
+		}
+	}
+	if len(diagnostics) != 0 {
+		return nil, diagnostics
+	}
+	return append(res, p.definitions...), nil
+}
+
+// Expand replaces the names of the prelude in ast with their terms.
+func (p *Prelude) Expand(ast entity.Ast) (entity.Ast, error) {
+	definitions, err := p.Definitions(ast.Term())
+	if err != nil {
+		return nil, err
+	}
+	return definitions.Expand(ast), nil
+}
+
+// numerals returns the variables of t named with digits only.
+func numerals(t entity.Term) []*entity.Var {
+	switch t := t.(type) {
+	case *entity.Var:
+		if t.Name != "" && strings.Trim(t.Name, "0123456789") == "" {
+			return []*entity.Var{t}
+		}
+	case *entity.Abs:
+		return numerals(t.Body)
+	case *entity.App:
+		return append(numerals(t.Fun), numerals(t.Arg)...)
+	case *entity.Let:
+		return append(numerals(t.Value), numerals(t.Body)...)
+	}
+	return nil
+}
+
+// numeral returns the Church numeral λf.λx.f_(…(f_x)) of n, which is not negative.
+func numeral(n int) entity.Term {
+	ast, _ := syntactical_analyzer.Encode(n, syntactical_analyzer.Shape{Kind: syntactical_analyzer.NAT})
+	return ast.Term()
+}
@@ -0,0 +1,243 @@
+package prelude
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	syntactical_analyzer "math-parser/pkg/syntactical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestPrelude(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Load every definition",
+			scenario: happyFlowLoadPrelude,
+		},
+		{
+			name:     "Happy flow. Normalize arithmetic",
+			scenario: happyFlowNormalizeArithmetic,
+		},
+		{
+			name:     "Happy flow. Normalize booleans, pairs and lists",
+			scenario: happyFlowNormalizeData,
+		},
+		{
+			name:     "Happy flow. Normalize combinators",
+			scenario: happyFlowNormalizeCombinators,
+		},
+		{
+			name:     "Happy flow. Decode normal forms",
+			scenario: happyFlowDecodePreludeResults,
+		},
+		{
+			name:     "Happy flow. Keep prelude names as references",
+			scenario: happyFlowPreludeReferences,
+		},
+		{
+			name:     "Negative flow. Look up unknown name",
+			scenario: negativeFlowLookupUnknownName,
+		},
+		{
+			name:     "Negative flow. Reject numerals above the maximum",
+			scenario: negativeFlowRejectLargeNumeral,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowLoadPrelude(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+
+	// act
+	prelude, err := Load(ctx)
+	plus, ok := prelude.Lookup("plus")
+	two, numeralOk := prelude.Lookup("2")
+	res, _ := syntactical_analyzer.Print(plus, syntactical_analyzer.PrintOptions{
+		Notation: syntactical_analyzer.Notation{Juxtaposition: true, Sugar: true},
+	})
+	twoRes, _ := syntactical_analyzer.Print(two, syntactical_analyzer.PrintOptions{
+		Notation: syntactical_analyzer.Notation{Juxtaposition: true, Sugar: true},
+	})
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Assert(t, ok)
+	assert.Assert(t, numeralOk)
+	assert.Equal(t, res, "λm n f x.m f (n f x)")
+	assert.Equal(t, twoRes, "λf x.f (f x)")
+	definitions, err := prelude.Definitions()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, definitions.Check(), nil)
+	for _, name := range []string{"true", "false", "succ", "mult", "pow", "pred", "sub", "pair", "fst", "cons", "nil", "Y", "Θ", "S", "K", "I"} {
+		_, ok := prelude.Lookup(name)
+		assert.Assert(t, ok, name)
+	}
+}
+
+func happyFlowNormalizeArithmetic(t *testing.T) {
+	assertNormalizesTo(t, [][2]string{
+		{"plus 2 3", "5"},
+		{"succ 0", "1"},
+		{"mult 2 3", "6"},
+		{"pow 2 3", "8"},
+		{"pred 3", "2"},
+		{"pred 0", "0"},
+		{"sub 5 2", "3"},
+		{"sub 2 5", "0"},
+		{"iszero 0", "true"},
+		{"eq (plus 1 2) 3", "true"},
+		{"leq 3 2", "false"},
+	})
+}
+
+func happyFlowNormalizeData(t *testing.T) {
+	assertNormalizesTo(t, [][2]string{
+		{"not (and true false)", "true"},
+		{"xor true true", "false"},
+		{"snd (pair a b)", "b"},
+		{"head (tail (cons a (cons b nil)))", "b"},
+		{"isnil (tail (cons a nil))", "true"},
+		{"length (cons a (cons b (cons c nil)))", "3"},
+		{"sum (map succ (cons 1 (cons 2 nil)))", "5"},
+		{"foldr cons nil (append (cons a nil) (cons b nil))", "cons a (cons b nil)"},
+	})
+}
+
+func happyFlowNormalizeCombinators(t *testing.T) {
+	assertNormalizesTo(t, [][2]string{
+		{"S K K a", "a"},
+		{"S (K (S I)) K a b", "b a"},
+		{"Y (λf n.iszero n 0 (f (pred n))) 3", "0"},
+		{"Θ (λf n.iszero n 1 (mult n (f (pred n)))) 3", "6"},
+	})
+}
+
+// assertNormalizesTo checks that both sides of every case, written with juxtaposition and the
+// names of the prelude, have alpha-equivalent normal forms.
+func assertNormalizesTo(t *testing.T, cases [][2]string) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := lexical_analysis.DefaultConfig()
+	config.Identifiers.Numerals = true
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, lexical_analysis.NewConfiguredAutomata(config))
+	parser := syntactical_analyzer.NewConfiguredLL1PredictableParser(ctx, syntactical_analyzer.Config{Juxtaposition: true})
+	prelude, err := Load(ctx)
+	assert.Equal(t, err, nil)
+
+	for _, c := range cases {
+		var normal [2]entity.Ast
+		for i, expr := range c {
+			// act
+			tk, _ := analyzer.Tokenize(expr)
+			ast, err := parser.Parse(tk)
+			assert.Equal(t, err, nil, expr)
+			ast, err = prelude.Expand(ast)
+			assert.Equal(t, err, nil, expr)
+			normal[i], err = parser.Reduce(ast, syntactical_analyzer.NewNormalOrderStrategy())
+			assert.Equal(t, err, nil, expr)
+		}
+
+		// assert
+		res, _ := parser.Unparse(normal[0])
+		assert.Assert(t, syntactical_analyzer.AlphaEquivalent(normal[0], normal[1]), "%s reduced to %s", c[0], res)
+	}
+}
+
+func happyFlowDecodePreludeResults(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := lexical_analysis.DefaultConfig()
+	config.Identifiers.Numerals = true
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, lexical_analysis.NewConfiguredAutomata(config))
+	parser := syntactical_analyzer.NewConfiguredLL1PredictableParser(ctx, syntactical_analyzer.Config{Juxtaposition: true})
+	prelude, _ := Load(ctx)
+	var tests = []struct {
+		input string
+		shape string
+		value interface{}
+	}{
+		{input: "plus 2 3", shape: "nat", value: 5},
+		{input: "leq 2 3", shape: "bool", value: true},
+		{input: "map succ (cons 1 (cons 2 nil))", shape: "list(nat)", value: []interface{}{2, 3}},
+		{input: "pair (iszero 1) (pow 3 2)", shape: "pair(bool,nat)", value: syntactical_analyzer.Pair{First: false, Second: 9}},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		ast, err = prelude.Expand(ast)
+		ast, err = parser.Reduce(ast, syntactical_analyzer.NewNormalOrderStrategy())
+		shape, err := syntactical_analyzer.ParseShape(test.shape)
+		res, err := parser.Decode(ast, shape)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.DeepEqual(t, res, test.value)
+	}
+}
+
+func happyFlowPreludeReferences(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := syntactical_analyzer.NewConfiguredLL1PredictableParser(ctx, syntactical_analyzer.Config{Juxtaposition: true})
+	prelude, _ := Load(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("fst (pair (not false) a)")
+	ast, err := parser.Parse(tk)
+	definitions, err := prelude.Definitions(ast.Term())
+	strategy := syntactical_analyzer.NewReferenceStrategy(syntactical_analyzer.NewNormalOrderStrategy(), definitions)
+	ast, err = parser.Reduce(ast, strategy)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "true")
+}
+
+func negativeFlowLookupUnknownName(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	prelude, _ := Load(ctx)
+
+	// act
+	_, ok := prelude.Lookup("fix")
+
+	// assert
+	assert.Assert(t, !ok)
+}
+
+func negativeFlowRejectLargeNumeral(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := lexical_analysis.DefaultConfig()
+	config.Identifiers.Numerals = true
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, lexical_analysis.NewConfiguredAutomata(config))
+	parser := syntactical_analyzer.NewConfiguredLL1PredictableParser(ctx, syntactical_analyzer.Config{Juxtaposition: true})
+	prelude, _ := Load(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("plus 10000 1000000000")
+	ast, err := parser.Parse(tk)
+	_, expandErr := prelude.Expand(ast)
+	_, ok := prelude.Lookup("10001")
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, expandErr.Error(), "1:12: error[P005]: numeral 1000000000 is larger than 10000")
+	assert.Assert(t, !ok)
+}
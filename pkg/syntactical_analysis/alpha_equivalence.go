@@ -0,0 +1,175 @@
+package syntactical_analyzer
+
+import (
+	"math-parser/pkg/entity"
+	"sort"
+	"strings"
+)
+
+// FreeVariables lists, in alphabetical order, the variables of ast that are not bound by an abstraction.
+func FreeVariables(ast entity.Ast) []string {
+	return sortedNames(freeVariables(ast.Term()))
+}
+
+// BoundVariables lists, in alphabetical order, the variables bound by the abstractions and the let bindings of ast.
+func BoundVariables(ast entity.Ast) []string {
+	res := map[string]bool{}
+	collectBoundVariables(ast.Term(), res)
+	return sortedNames(res)
+}
+
+func collectBoundVariables(t entity.Term, res map[string]bool) {
+	switch t := t.(type) {
+	case *entity.Abs:
+		res[t.Param] = true
+		collectBoundVariables(t.Body, res)
+	case *entity.App:
+		collectBoundVariables(t.Fun, res)
+		collectBoundVariables(t.Arg, res)
+	case *entity.Let:
+		res[t.Name] = true
+		collectBoundVariables(t.Value, res)
+		collectBoundVariables(t.Body, res)
+	}
+}
+
+func sortedNames(names map[string]bool) []string {
+	res := make([]string, 0, len(names))
+	for name := range names {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// AlphaEquivalent reports whether a and b are the same term up to the names of their bound variables.
+func AlphaEquivalent(a entity.Ast, b entity.Ast) bool {
+	return alphaEquivalent(a.Term(), b.Term(), map[string]int{}, map[string]int{}, 0)
+}
+
+// alphaEquivalent compares m and n, where envM and envN map a bound variable to the
+// depth of the abstraction or let binding it. The value of a let is compared outside
+// its binder, and the value of a letrec inside.
+func alphaEquivalent(m entity.Term, n entity.Term, envM map[string]int, envN map[string]int, depth int) bool {
+	switch m := m.(type) {
+	case *entity.Var:
+		n, ok := n.(*entity.Var)
+		if !ok {
+			return false
+		}
+		depthM, boundM := envM[m.Name]
+		depthN, boundN := envN[n.Name]
+		if boundM || boundN {
+			return boundM && boundN && depthM == depthN
+		}
+		return m.Name == n.Name
+	case *entity.Abs:
+		n, ok := n.(*entity.Abs)
+		if !ok {
+			return false
+		}
+		outerM, shadowsM := envM[m.Param]
+		outerN, shadowsN := envN[n.Param]
+		envM[m.Param], envN[n.Param] = depth, depth
+		res := alphaEquivalent(m.Body, n.Body, envM, envN, depth+1)
+		restoreBinding(envM, m.Param, outerM, shadowsM)
+		restoreBinding(envN, n.Param, outerN, shadowsN)
+		return res
+	case *entity.App:
+		n, ok := n.(*entity.App)
+		if !ok {
+			return false
+		}
+		return alphaEquivalent(m.Fun, n.Fun, envM, envN, depth) && alphaEquivalent(m.Arg, n.Arg, envM, envN, depth)
+	case *entity.Let:
+		n, ok := n.(*entity.Let)
+		if !ok || m.Recursive != n.Recursive {
+			return false
+		}
+		if !m.Recursive && !alphaEquivalent(m.Value, n.Value, envM, envN, depth) {
+			return false
+		}
+		outerM, shadowsM := envM[m.Name]
+		outerN, shadowsN := envN[n.Name]
+		envM[m.Name], envN[n.Name] = depth, depth
+		res := (!m.Recursive || alphaEquivalent(m.Value, n.Value, envM, envN, depth+1)) &&
+			alphaEquivalent(m.Body, n.Body, envM, envN, depth+1)
+		restoreBinding(envM, m.Name, outerM, shadowsM)
+		restoreBinding(envN, n.Name, outerN, shadowsN)
+		return res
+	default:
+		return false
+	}
+}
+
+func restoreBinding(env map[string]int, name string, depth int, ok bool) {
+	if ok {
+		env[name] = depth
+	} else {
+		delete(env, name)
+	}
+}
+
+// Canonicalize renames the bound variables of ast after the depth of their abstraction or let,
+// using a, b, …, z, a', b', … and skipping the free variables of ast. Alpha-equivalent
+// terms have the same canonical form.
+func Canonicalize(ast entity.Ast) entity.Ast {
+	term := ast.Term()
+	return entity.NewAst(canonicalize(term, canonicalNames(freeVariables(term)), map[string]string{}, 0))
+}
+
+func canonicalize(t entity.Term, names func(int) string, env map[string]string, depth int) entity.Term {
+	switch t := t.(type) {
+	case *entity.Var:
+		if name, ok := env[t.Name]; ok {
+			return t.Rename(name)
+		}
+		return t
+	case *entity.Abs:
+		outer, shadows := env[t.Param]
+		env[t.Param] = names(depth)
+		res := t.Rebuild(env[t.Param], canonicalize(t.Body, names, env, depth+1))
+		restoreName(env, t.Param, outer, shadows)
+		return res
+	case *entity.App:
+		return t.Rebuild(canonicalize(t.Fun, names, env, depth), canonicalize(t.Arg, names, env, depth))
+	case *entity.Let:
+		value := t.Value
+		if !t.Recursive {
+			value = canonicalize(value, names, env, depth)
+		}
+		outer, shadows := env[t.Name]
+		env[t.Name] = names(depth)
+		if t.Recursive {
+			value = canonicalize(value, names, env, depth+1)
+		}
+		res := rebuildLet(t, env[t.Name], value, canonicalize(t.Body, names, env, depth+1))
+		restoreName(env, t.Name, outer, shadows)
+		return res
+	default:
+		return t
+	}
+}
+
+func restoreName(env map[string]string, name string, outer string, ok bool) {
+	if ok {
+		env[name] = outer
+	} else {
+		delete(env, name)
+	}
+}
+
+// canonicalNames returns the name of the binder at each depth, avoiding the reserved names.
+func canonicalNames(reserved map[string]bool) func(int) string {
+	var names []string
+	candidate := 0
+	return func(depth int) string {
+		for ; len(names) <= depth; candidate++ {
+			name := string(rune('a'+candidate%26)) + strings.Repeat("'", candidate/26)
+			if !reserved[name] {
+				names = append(names, name)
+			}
+		}
+		return names[depth]
+	}
+}
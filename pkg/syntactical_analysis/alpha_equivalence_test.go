@@ -0,0 +1,192 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestAlphaEquivalent(t *testing.T) {
+	var tests = []struct {
+		name       string
+		a          string
+		b          string
+		equivalent bool
+	}{
+		{name: "Same variable", a: "x", b: "x", equivalent: true},
+		{name: "Different free variables", a: "x", b: "y", equivalent: false},
+		{name: "Renamed binder", a: "λx.x", b: "λy.y", equivalent: true},
+		{name: "Renamed nested binders", a: "λx.λy.x_y", b: "λy.λx.y_x", equivalent: true},
+		{name: "Swapped binders", a: "λx.λy.x_y", b: "λx.λy.y_x", equivalent: false},
+		{name: "Bound against free variable", a: "λx.x", b: "λx.y", equivalent: false},
+		{name: "Capturing renaming", a: "λx.y", b: "λy.y", equivalent: false},
+		{name: "Shadowed binder", a: "λx.λx.x", b: "λx.λy.y", equivalent: true},
+		{name: "Shadowed binder refers to inner abstraction", a: "λx.λx.x", b: "λx.λy.x", equivalent: false},
+		{name: "Brackets", a: "(λx.(x))_y", b: "(λz.z)_y", equivalent: true},
+		{name: "Different shapes", a: "λx.x_x", b: "x_x", equivalent: false},
+	}
+
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, lexical_analysis.NewAutomata())
+	parser := NewLL1PredictableParser(ctx)
+	parse := func(t *testing.T, expression string) entity.Ast {
+		tk, err := analyzer.Tokenize(expression)
+		assert.Equal(t, err, nil)
+		ast, err := parser.Parse(tk)
+		assert.Equal(t, err, nil)
+		return ast
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := parse(t, test.a), parse(t, test.b)
+			assert.Equal(t, AlphaEquivalent(a, b), test.equivalent)
+			assert.Equal(t, AlphaEquivalent(b, a), test.equivalent)
+
+			canonicalA, err := parser.Unparse(Canonicalize(a))
+			assert.Equal(t, err, nil)
+			canonicalB, err := parser.Unparse(Canonicalize(b))
+			assert.Equal(t, err, nil)
+			assert.Equal(t, canonicalA == canonicalB, test.equivalent)
+		})
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Canonicalize skips free variables",
+			scenario: happyFlowCanonicalizeSkipsFreeVariables,
+		},
+		{
+			name:     "Happy flow. Free and bound variables",
+			scenario: happyFlowFreeAndBoundVariables,
+		},
+		{
+			name:     "Happy flow. Beta reduction result is alpha-equivalent to the expected term",
+			scenario: happyFlowBetaReductionAlphaEquivalentToExpected,
+		},
+		{
+			name:     "Happy flow. Kept let bindings round-trip through the parser",
+			scenario: happyFlowKeptLetRoundTrip,
+		},
+		{
+			name:     "Happy flow. Free and bound variables of let bindings",
+			scenario: happyFlowFreeAndBoundVariablesOfLet,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowCanonicalizeSkipsFreeVariables(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.λy.a_x_(λz.z)_y")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(Canonicalize(ast))
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λb.(λc.(((a_b)_(λd.d))_c)))")
+}
+
+func happyFlowFreeAndBoundVariables(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("x_λx.λy.x_z_(λz.y)")
+	ast, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.DeepEqual(t, FreeVariables(ast), []string{"x", "z"})
+	assert.DeepEqual(t, BoundVariables(ast), []string{"x", "y", "z"})
+}
+
+func happyFlowBetaReductionAlphaEquivalentToExpected(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.λy.λz.x_y_z)_(y_z)")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.BetaReduce(ast)
+	tk, _ = analyzer.Tokenize("λa.λb.(y_z)_a_b")
+	expected, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Assert(t, AlphaEquivalent(ast, expected))
+}
+
+func happyFlowKeptLetRoundTrip(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true, KeepLet: true})
+	parse := func(expression string) entity.Ast {
+		tk, _ := analyzer.Tokenize(expression)
+		ast, err := parser.Parse(tk)
+		assert.Equal(t, err, nil)
+		return ast
+	}
+
+	// act
+	ast := parse("let x = x in letrec f = λn.f (x n) in λx.f x")
+	printed, err := Print(ast, PrintOptions{Notation: Notation{Juxtaposition: true}})
+	again := parse(printed)
+	canonical, err := Print(Canonicalize(ast), PrintOptions{Notation: Notation{Juxtaposition: true}})
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Assert(t, AlphaEquivalent(ast, again))
+	assert.Assert(t, AlphaEquivalent(ast, parse("let y = x in letrec g = λm.g (y m) in λz.g z")))
+	assert.Assert(t, !AlphaEquivalent(ast, parse("let y = y in letrec g = λm.g (y m) in λz.g z")))
+	assert.Assert(t, !AlphaEquivalent(ast, parse("let y = x in let g = λm.g (y m) in λz.g z")))
+	assert.Equal(t, canonical, "let a = x in letrec b = λc.b (a c) in λc.b c")
+	assert.Assert(t, AlphaEquivalent(ast, parse(canonical)))
+}
+
+func happyFlowFreeAndBoundVariablesOfLet(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true, KeepLet: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("let x = y in x")
+	let, err := parser.Parse(tk)
+	tk, _ = analyzer.Tokenize("(let x = x in x) (letrec f = g f in f)")
+	lets, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.DeepEqual(t, FreeVariables(let), []string{"y"})
+	assert.DeepEqual(t, BoundVariables(let), []string{"x"})
+	assert.DeepEqual(t, FreeVariables(lets), []string{"g", "x"})
+	assert.DeepEqual(t, BoundVariables(lets), []string{"f", "x"})
+}
@@ -0,0 +1,125 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Associativity(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Parse left-associative application",
+			scenario: happyFlowParseLeftAssociativeApplication,
+		},
+		{
+			name:     "Happy flow. Parse right-associative application",
+			scenario: happyFlowParseRightAssociativeApplication,
+		},
+		{
+			name:     "Happy flow. Reduce multi-argument application",
+			scenario: happyFlowReduceMultiArgumentApplication,
+		},
+		{
+			name:     "Happy flow. Unparse preserves meaning",
+			scenario: happyFlowUnparsePreservesMeaning,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowParseLeftAssociativeApplication(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("x_y_(z_w)_λv.v_x")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(((x_y)_(z_w))_(λv.(v_x)))")
+}
+
+func happyFlowParseRightAssociativeApplication(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{RightAssociative: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("x_y_(z_w)_λv.v_x")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(x_(y_((z_w)_(λv.(v_x)))))")
+}
+
+func happyFlowReduceMultiArgumentApplication(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λf.λx.f_(f_x))_g_a")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.Reduce(ast, NewNormalOrderStrategy())
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(g_(g_a))")
+}
+
+func happyFlowUnparsePreservesMeaning(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	configs := []Config{{}, {RightAssociative: true}}
+	expressions := []string{
+		"x_y_z",
+		"x_(y_z)",
+		"(x_y)_z",
+		"x_λy.y_z_w",
+		"(λx.λy.x)_a_b",
+		"(λf.λx.f_(f_x))_(λy.y_z)_(λs.s_s)",
+	}
+
+	for _, config := range configs {
+		parser := NewConfiguredLL1PredictableParser(ctx, config)
+		for _, expression := range expressions {
+			// act
+			tk, _ := analyzer.Tokenize(expression)
+			ast, err := parser.Parse(tk)
+			res, err := parser.Unparse(ast)
+			tk, _ = analyzer.Tokenize(res)
+			again, err := parser.Parse(tk)
+			normal, err := parser.Reduce(ast, NewNormalOrderStrategy())
+			againNormal, err := parser.Reduce(again, NewNormalOrderStrategy())
+
+			// assert
+			assert.Equal(t, err, nil)
+			assert.Assert(t, AlphaEquivalent(ast, again), expression)
+			assert.Assert(t, AlphaEquivalent(normal, againNormal), expression)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package syntactical_analyzer
+
+import (
+	"errors"
+	"fmt"
+	"math-parser/pkg/entity"
+	"strconv"
+	"unicode"
+)
+
+// DeBruijnTerm is a nameless term. A variable is the number of abstractions between it and
+// its binder, counting from 1. Indices beyond the enclosing abstractions refer to free variables.
+type DeBruijnTerm interface {
+	isDeBruijnTerm()
+}
+
+type DeBruijnIndex struct {
+	Index int
+}
+
+type DeBruijnAbstraction struct {
+	Body DeBruijnTerm
+}
+
+type DeBruijnApplication struct {
+	Fun DeBruijnTerm
+	Arg DeBruijnTerm
+}
+
+func (DeBruijnIndex) isDeBruijnTerm()       {}
+func (DeBruijnAbstraction) isDeBruijnTerm() {}
+func (DeBruijnApplication) isDeBruijnTerm() {}
+
+// ToDeBruijn converts ast to the nameless representation. The index depth+i of a variable
+// under depth abstractions refers to free[i-1], free variables are listed alphabetically.
+func ToDeBruijn(ast entity.Ast) (term DeBruijnTerm, free []string, err error) {
+	free = FreeVariables(ast)
+	context := make([]string, len(free))
+	for i, name := range free {
+		context[len(free)-1-i] = name
+	}
+	term, err = toDeBruijn(ast.Term(), context)
+	return term, free, err
+}
+
+// toDeBruijn converts t, where context lists the names in scope, the innermost one last.
+func toDeBruijn(t entity.Term, context []string) (DeBruijnTerm, error) {
+	switch t := t.(type) {
+	case *entity.Var:
+		for i := len(context) - 1; i >= 0; i-- {
+			if context[i] == t.Name {
+				return DeBruijnIndex{Index: len(context) - i}, nil
+			}
+		}
+		return nil, fmt.Errorf("variable %s is not in context", t.Name)
+	case *entity.Abs:
+		body, err := toDeBruijn(t.Body, append(context[:len(context):len(context)], t.Param))
+		if err != nil {
+			return nil, err
+		}
+		return DeBruijnAbstraction{Body: body}, nil
+	case *entity.App:
+		fun, err := toDeBruijn(t.Fun, context)
+		if err != nil {
+			return nil, err
+		}
+		arg, err := toDeBruijn(t.Arg, context)
+		if err != nil {
+			return nil, err
+		}
+		return DeBruijnApplication{Fun: fun, Arg: arg}, nil
+	default:
+		return nil, errMalformedTerm
+	}
+}
+
+// FromDeBruijn names the nameless term, free lists the names of its free variables as
+// ToDeBruijn does. Binders are named like in Canonicalize.
+func FromDeBruijn(term DeBruijnTerm, free []string) (entity.Ast, error) {
+	reserved := map[string]bool{}
+	context := make([]string, len(free))
+	for i, name := range free {
+		reserved[name] = true
+		context[len(free)-1-i] = name
+	}
+	res, err := fromDeBruijn(term, context, canonicalNames(reserved), len(free))
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewAst(res), nil
+}
+
+func fromDeBruijn(term DeBruijnTerm, context []string, names func(int) string, free int) (entity.Term, error) {
+	switch t := term.(type) {
+	case DeBruijnIndex:
+		if t.Index < 1 || t.Index > len(context) {
+			return nil, fmt.Errorf("index %d is out of context", t.Index)
+		}
+		return entity.NewVar(context[len(context)-t.Index]), nil
+	case DeBruijnAbstraction:
+		binder := names(len(context) - free)
+		body, err := fromDeBruijn(t.Body, append(context[:len(context):len(context)], binder), names, free)
+		if err != nil {
+			return nil, err
+		}
+		return entity.NewAbs(binder, body), nil
+	case DeBruijnApplication:
+		fun, err := fromDeBruijn(t.Fun, context, names, free)
+		if err != nil {
+			return nil, err
+		}
+		arg, err := fromDeBruijn(t.Arg, context, names, free)
+		if err != nil {
+			return nil, err
+		}
+		return entity.NewApp(fun, arg), nil
+	default:
+		return nil, errMalformedTerm
+	}
+}
+
+// UnparseDeBruijn prints term like λ λ 2 1: application is left-associative juxtaposition
+// and an abstraction extends as far right as possible.
+func UnparseDeBruijn(term DeBruijnTerm) string {
+	switch t := term.(type) {
+	case DeBruijnIndex:
+		return strconv.Itoa(t.Index)
+	case DeBruijnAbstraction:
+		return LAMBDA + " " + UnparseDeBruijn(t.Body)
+	case DeBruijnApplication:
+		fun := UnparseDeBruijn(t.Fun)
+		if _, ok := t.Fun.(DeBruijnAbstraction); ok {
+			fun = "(" + fun + ")"
+		}
+		arg := UnparseDeBruijn(t.Arg)
+		if _, ok := t.Arg.(DeBruijnIndex); !ok {
+			arg = "(" + arg + ")"
+		}
+		return fun + " " + arg
+	default:
+		return ""
+	}
+}
+
+// ParseDeBruijn reads the output of UnparseDeBruijn back, \ is accepted in place of λ.
+// It follows the LL(1) grammar
+//
+//	D ⟶ λ D | A As
+//	As ⟶ ε | A As | λ D
+//	A ⟶ n | ( D )
+func ParseDeBruijn(input string) (DeBruijnTerm, error) {
+	p := &deBruijnParser{input: []rune(input)}
+	res, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	if p.lookahead() != 0 {
+		return nil, fmt.Errorf("unexpected %q at %d", p.lookahead(), p.pos)
+	}
+	return res, nil
+}
+
+type deBruijnParser struct {
+	input []rune
+	pos   int
+}
+
+func (p *deBruijnParser) lookahead() rune {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+	if p.pos == len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *deBruijnParser) term() (DeBruijnTerm, error) {
+	if r := p.lookahead(); r == 'λ' || r == '\\' {
+		p.pos++
+		body, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		return DeBruijnAbstraction{Body: body}, nil
+	}
+	res, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if r := p.lookahead(); r == 'λ' || r == '\\' {
+			arg, err := p.term()
+			if err != nil {
+				return nil, err
+			}
+			return DeBruijnApplication{Fun: res, Arg: arg}, nil
+		}
+		if r := p.lookahead(); r != '(' && !unicode.IsDigit(r) {
+			return res, nil
+		}
+		arg, err := p.atom()
+		if err != nil {
+			return nil, err
+		}
+		res = DeBruijnApplication{Fun: res, Arg: arg}
+	}
+}
+
+func (p *deBruijnParser) atom() (DeBruijnTerm, error) {
+	switch r := p.lookahead(); {
+	case r == '(':
+		p.pos++
+		res, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if p.lookahead() != ')' {
+			return nil, fmt.Errorf("expected ) at %d", p.pos)
+		}
+		p.pos++
+		return res, nil
+	case unicode.IsDigit(r):
+		start := p.pos
+		for p.pos < len(p.input) && unicode.IsDigit(p.input[p.pos]) {
+			p.pos++
+		}
+		index, err := strconv.Atoi(string(p.input[start:p.pos]))
+		if err != nil {
+			return nil, err
+		}
+		if index == 0 {
+			return nil, errors.New("de Bruijn indices start from 1")
+		}
+		return DeBruijnIndex{Index: index}, nil
+	case r == 0:
+		return nil, errors.New("unexpected end of input")
+	default:
+		return nil, fmt.Errorf("unexpected %q at %d", r, p.pos)
+	}
+}
@@ -0,0 +1,137 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestDeBruijn(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Convert to de Bruijn indices",
+			scenario: happyFlowConvertToDeBruijn,
+		},
+		{
+			name:     "Happy flow. Convert term with free variables",
+			scenario: happyFlowConvertFreeVariablesToDeBruijn,
+		},
+		{
+			name:     "Happy flow. Convert from de Bruijn indices",
+			scenario: happyFlowConvertFromDeBruijn,
+		},
+		{
+			name:     "Happy flow. Parse de Bruijn term",
+			scenario: happyFlowParseDeBruijn,
+		},
+		{
+			name:     "Negative flow. Parse de Bruijn term with unbalanced brackets",
+			scenario: negativeFlowParseDeBruijnWithUnbalancedBrackets,
+		},
+		{
+			name:     "Negative flow. Convert de Bruijn index out of context",
+			scenario: negativeFlowConvertDeBruijnIndexOutOfContext,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowConvertToDeBruijn(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.λy.x_y")
+	ast, err := parser.Parse(tk)
+	term, free, err := ToDeBruijn(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(free), 0)
+	assert.Equal(t, UnparseDeBruijn(term), "λ λ 2 1")
+}
+
+func happyFlowConvertFreeVariablesToDeBruijn(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x_z)_λy.y_a_(λx.x)")
+	ast, err := parser.Parse(tk)
+	term, free, err := ToDeBruijn(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.DeepEqual(t, free, []string{"a", "z"})
+	assert.Equal(t, UnparseDeBruijn(term), "(λ 1 3) (λ 1 2 (λ 1))")
+}
+
+func happyFlowConvertFromDeBruijn(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λa.λy.(a_y)_b_(λb.b_y)")
+	ast, err := parser.Parse(tk)
+	term, free, err := ToDeBruijn(ast)
+	named, err := FromDeBruijn(term, free)
+	res, err := parser.Unparse(named)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Assert(t, AlphaEquivalent(ast, named))
+	assert.Equal(t, res, "(λa.(λc.(((a_c)_b)_(λd.(d_c)))))")
+}
+
+func happyFlowParseDeBruijn(t *testing.T) {
+	// act
+	term, err := ParseDeBruijn(`λ (\ 1 2) 1 λ 2`)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, UnparseDeBruijn(term), "λ (λ 1 2) 1 (λ 2)")
+	assert.DeepEqual(t, term, DeBruijnAbstraction{
+		Body: DeBruijnApplication{
+			Fun: DeBruijnApplication{
+				Fun: DeBruijnAbstraction{Body: DeBruijnApplication{Fun: DeBruijnIndex{Index: 1}, Arg: DeBruijnIndex{Index: 2}}},
+				Arg: DeBruijnIndex{Index: 1},
+			},
+			Arg: DeBruijnAbstraction{Body: DeBruijnIndex{Index: 2}},
+		},
+	})
+}
+
+func negativeFlowParseDeBruijnWithUnbalancedBrackets(t *testing.T) {
+	// act
+	_, err := ParseDeBruijn("λ (1 1")
+
+	// assert
+	assert.ErrorContains(t, err, "expected )")
+}
+
+func negativeFlowConvertDeBruijnIndexOutOfContext(t *testing.T) {
+	// act
+	term, err := ParseDeBruijn("λ 1 2")
+	_, err = FromDeBruijn(term, nil)
+
+	// assert
+	assert.ErrorContains(t, err, "index 2 is out of context")
+}
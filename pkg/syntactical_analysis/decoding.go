@@ -0,0 +1,357 @@
+package syntactical_analyzer
+
+import (
+	"fmt"
+	"math-parser/pkg/entity"
+	"strings"
+)
+
+type Kind string
+
+const (
+	// NAT is a Church numeral λf x.f (… (f x)), decoded to an int.
+	NAT Kind = "nat"
+	// SCOTT_NAT is a Scott numeral, λz s.z for 0 and λz s.s n for n+1, decoded to an int.
+	SCOTT_NAT Kind = "scott-nat"
+	// BOOL is a Church boolean λt f.t or λt f.f, decoded to a bool.
+	BOOL Kind = "bool"
+	// LIST is a Church list, the right fold λc n.c x1 (c x2 (… n)), decoded to a []interface{}.
+	LIST Kind = "list"
+	// SCOTT_LIST is a Scott list, λn c.n when empty and λn c.c x xs otherwise, decoded to a []interface{}.
+	SCOTT_LIST Kind = "scott-list"
+	// PAIR is a Church pair λf.f x y, decoded to a Pair.
+	PAIR Kind = "pair"
+	// SUM is the i-th alternative λc1 … cn.ci x of a tagged sum, decoded to a Sum.
+	SUM Kind = "sum"
+	// RAW is any term, decoded to the string Unparse prints.
+	RAW Kind = "term"
+)
+
+// Shape tells how to decode a term: its kind, with the shape of the elements of a list, of the
+// two components of a pair, or of the value of every alternative of a sum. It reads like
+// list(pair(nat,bool)), and the shapes left out are RAW.
+type Shape struct {
+	Kind  Kind
+	Elems []Shape
+}
+
+// Pair is a decoded pair.
+type Pair struct {
+	First  interface{}
+	Second interface{}
+}
+
+func (p Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// Sum is a decoded alternative of a sum: the value of the alternative numbered Tag, from 0.
+type Sum struct {
+	Tag   int
+	Value interface{}
+}
+
+func (s Sum) String() string {
+	return fmt.Sprintf("#%d %v", s.Tag, s.Value)
+}
+
+// DecodingError reports a term that does not have the expected shape.
+type DecodingError struct {
+	Shape Shape
+	Term  string
+}
+
+func (e *DecodingError) Error() string {
+	return fmt.Sprintf("could not decode %s as %v", e.Term, e.Shape)
+}
+
+func (s Shape) String() string {
+	if len(s.Elems) == 0 {
+		return string(s.Kind)
+	}
+	elems := make([]string, len(s.Elems))
+	for i, e := range s.Elems {
+		elems[i] = e.String()
+	}
+	return fmt.Sprintf("%s(%s)", s.Kind, strings.Join(elems, ","))
+}
+
+// ParseShape reads a shape like nat, list or pair(nat,list(bool)).
+func ParseShape(input string) (Shape, error) {
+	res, rest, err := parseShape(strings.ReplaceAll(input, " ", ""))
+	if err == nil && rest != "" {
+		err = fmt.Errorf("unexpected %q after shape", rest)
+	}
+	return res, err
+}
+
+func parseShape(input string) (Shape, string, error) {
+	end := strings.IndexAny(input, "(),")
+	if end < 0 {
+		end = len(input)
+	}
+	res := Shape{Kind: Kind(input[:end])}
+	arity, ok := map[Kind]int{NAT: 0, SCOTT_NAT: 0, BOOL: 0, LIST: 1, SCOTT_LIST: 1, PAIR: 2, SUM: -1, RAW: 0}[res.Kind]
+	if !ok {
+		return Shape{}, "", fmt.Errorf("unknown shape %q", input[:end])
+	}
+	rest := input[end:]
+	if strings.HasPrefix(rest, "(") {
+		for len(res.Elems) == 0 || strings.HasPrefix(rest, ",") {
+			elem, r, err := parseShape(rest[1:])
+			if err != nil {
+				return Shape{}, "", err
+			}
+			res.Elems, rest = append(res.Elems, elem), r
+		}
+		if !strings.HasPrefix(rest, ")") {
+			return Shape{}, "", fmt.Errorf("missing ) in shape %s", res)
+		}
+		rest = rest[1:]
+	}
+	if arity < 0 && len(res.Elems) == 0 {
+		return Shape{}, "", fmt.Errorf("%s takes the shapes of its alternatives", res.Kind)
+	}
+	if arity >= 0 && len(res.Elems) != 0 && len(res.Elems) != arity {
+		return Shape{}, "", fmt.Errorf("%s takes %d shapes, not %d", res.Kind, arity, len(res.Elems))
+	}
+	for len(res.Elems) < arity {
+		res.Elems = append(res.Elems, Shape{Kind: RAW})
+	}
+	return res, rest, nil
+}
+
+// elem returns the i-th shape of s, RAW if left out.
+func (s Shape) elem(i int) Shape {
+	if i < len(s.Elems) {
+		return s.Elems[i]
+	}
+	return Shape{Kind: RAW}
+}
+
+// Decode reads the Go value encoded by ast, which should be in normal form, or returns
+// a DecodingError when it does not have the given shape.
+func (l *lL1PredictableParser) Decode(ast entity.Ast, shape Shape) (interface{}, error) {
+	res, ok := l.decode(ast.Term(), shape)
+	if !ok {
+		term, err := l.Unparse(ast)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &DecodingError{Shape: shape, Term: term}
+	}
+	l.logging.Debugf("decoded %v as %v", res, shape)
+	return res, nil
+}
+
+func (l *lL1PredictableParser) decode(t entity.Term, shape Shape) (interface{}, bool) {
+	switch shape.Kind {
+	case NAT:
+		return decodeNat(t)
+	case SCOTT_NAT:
+		return decodeScottNat(t)
+	case BOOL:
+		return decodeBool(t)
+	case LIST:
+		return l.decodeList(t, shape.elem(0))
+	case SCOTT_LIST:
+		return l.decodeScottList(t, shape.elem(0))
+	case PAIR:
+		return l.decodePair(t, shape.elem(0), shape.elem(1))
+	case SUM:
+		return l.decodeSum(t, shape.Elems)
+	case RAW:
+		res, err := l.Unparse(entity.NewAst(t))
+		return res, err == nil
+	default:
+		return nil, false
+	}
+}
+
+func decodeNat(t entity.Term) (interface{}, bool) {
+	params, body, ok := abstractions(t, 2)
+	if !ok {
+		return nil, false
+	}
+	f, x := params[0], params[1]
+	res := 0
+	for {
+		switch b := body.(type) {
+		case *entity.Var:
+			return res, b.Name == x
+		case *entity.App:
+			if !isVar(b.Fun, f) {
+				return nil, false
+			}
+			res++
+			body = b.Arg
+		default:
+			return nil, false
+		}
+	}
+}
+
+func decodeScottNat(t entity.Term) (interface{}, bool) {
+	res := 0
+	for {
+		params, body, ok := abstractions(t, 2)
+		if !ok {
+			return nil, false
+		}
+		if isVar(body, params[0]) {
+			return res, true
+		}
+		app, ok := body.(*entity.App)
+		if !ok || !isVar(app.Fun, params[1]) || freeVariables(app.Arg)[params[0]] || freeVariables(app.Arg)[params[1]] {
+			return nil, false
+		}
+		res, t = res+1, app.Arg
+	}
+}
+
+func decodeBool(t entity.Term) (interface{}, bool) {
+	params, body, ok := abstractions(t, 2)
+	switch {
+	case !ok:
+		return nil, false
+	case isVar(body, params[0]):
+		return true, true
+	case isVar(body, params[1]):
+		return false, true
+	default:
+		return nil, false
+	}
+}
+
+func (l *lL1PredictableParser) decodeList(t entity.Term, elem Shape) (interface{}, bool) {
+	params, body, ok := abstractions(t, 2)
+	if !ok {
+		return nil, false
+	}
+	res := []interface{}{}
+	for !isVar(body, params[1]) {
+		head, tail, ok := cell(body, params[0], params)
+		if !ok {
+			return nil, false
+		}
+		value, ok := l.decode(head, elem)
+		if !ok {
+			return nil, false
+		}
+		res, body = append(res, value), tail
+	}
+	return res, true
+}
+
+func (l *lL1PredictableParser) decodeScottList(t entity.Term, elem Shape) (interface{}, bool) {
+	res := []interface{}{}
+	for {
+		params, body, ok := abstractions(t, 2)
+		if !ok {
+			return nil, false
+		}
+		if isVar(body, params[0]) {
+			return res, true
+		}
+		head, tail, ok := cell(body, params[1], params)
+		if !ok || freeVariables(tail)[params[0]] || freeVariables(tail)[params[1]] {
+			return nil, false
+		}
+		value, ok := l.decode(head, elem)
+		if !ok {
+			return nil, false
+		}
+		res, t = append(res, value), tail
+	}
+}
+
+func (l *lL1PredictableParser) decodePair(t entity.Term, first Shape, second Shape) (interface{}, bool) {
+	params, body, ok := abstractions(t, 1)
+	if !ok {
+		return nil, false
+	}
+	app, ok := body.(*entity.App)
+	if !ok {
+		return nil, false
+	}
+	inner, ok := app.Fun.(*entity.App)
+	if !ok || !isVar(inner.Fun, params[0]) || freeVariables(inner.Arg)[params[0]] || freeVariables(app.Arg)[params[0]] {
+		return nil, false
+	}
+	x, ok := l.decode(inner.Arg, first)
+	if !ok {
+		return nil, false
+	}
+	y, ok := l.decode(app.Arg, second)
+	if !ok {
+		return nil, false
+	}
+	return Pair{First: x, Second: y}, true
+}
+
+func (l *lL1PredictableParser) decodeSum(t entity.Term, alternatives []Shape) (interface{}, bool) {
+	params, body, ok := abstractions(t, len(alternatives))
+	if !ok {
+		return nil, false
+	}
+	app, ok := body.(*entity.App)
+	if !ok {
+		return nil, false
+	}
+	for _, p := range params {
+		if freeVariables(app.Arg)[p] {
+			return nil, false
+		}
+	}
+	for i, p := range params {
+		if isVar(app.Fun, p) {
+			value, ok := l.decode(app.Arg, alternatives[i])
+			if !ok {
+				return nil, false
+			}
+			return Sum{Tag: i, Value: value}, true
+		}
+	}
+	return nil, false
+}
+
+// abstractions returns the n parameters of t, which must be distinct, and the body under them.
+func abstractions(t entity.Term, n int) ([]string, entity.Term, bool) {
+	var params []string
+	for len(params) < n {
+		abs, ok := t.(*entity.Abs)
+		if !ok {
+			return nil, nil, false
+		}
+		for _, p := range params {
+			if p == abs.Param {
+				return nil, nil, false
+			}
+		}
+		params, t = append(params, abs.Param), abs.Body
+	}
+	return params, t, true
+}
+
+// cell splits the application c_x_xs, where x does not refer to params.
+func cell(t entity.Term, c string, params []string) (entity.Term, entity.Term, bool) {
+	app, ok := t.(*entity.App)
+	if !ok {
+		return nil, nil, false
+	}
+	inner, ok := app.Fun.(*entity.App)
+	if !ok || !isVar(inner.Fun, c) {
+		return nil, nil, false
+	}
+	for _, p := range params {
+		if freeVariables(inner.Arg)[p] {
+			return nil, nil, false
+		}
+	}
+	return inner.Arg, app.Arg, true
+}
+
+func isVar(t entity.Term, name string) bool {
+	v, ok := t.(*entity.Var)
+	return ok && v.Name == name
+}
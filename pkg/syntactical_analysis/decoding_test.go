@@ -0,0 +1,220 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Decode(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Decode Church numerals and booleans",
+			scenario: happyFlowDecodeNatAndBool,
+		},
+		{
+			name:     "Happy flow. Decode lists and pairs",
+			scenario: happyFlowDecodeListsAndPairs,
+		},
+		{
+			name:     "Happy flow. Decode Scott numerals and sums",
+			scenario: happyFlowDecodeScottNatAndSum,
+		},
+		{
+			name:     "Happy flow. Decode with shapes leaving out their elements",
+			scenario: happyFlowDecodeBareShapes,
+		},
+		{
+			name:     "Happy flow. Parse shapes",
+			scenario: happyFlowParseShapes,
+		},
+		{
+			name:     "Negative flow. Reject unknown shapes",
+			scenario: negativeFlowParseUnknownShapes,
+		},
+		{
+			name:     "Negative flow. Report term that could not be decoded",
+			scenario: negativeFlowDecodeMismatch,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowDecodeNatAndBool(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+	var tests = []struct {
+		input string
+		shape Shape
+		value interface{}
+	}{
+		{input: "λf.λx.f_(f_(f_x))", shape: Shape{Kind: NAT}, value: 3},
+		{input: "λs.λz.z", shape: Shape{Kind: NAT}, value: 0},
+		{input: "λa.λb.a", shape: Shape{Kind: BOOL}, value: true},
+		{input: "λa.λb.b", shape: Shape{Kind: BOOL}, value: false},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		res, err := parser.Decode(ast, test.shape)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res, test.value, test.input)
+	}
+}
+
+func happyFlowDecodeListsAndPairs(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	var tests = []struct {
+		input string
+		shape string
+		value interface{}
+	}{
+		{input: "λc n.c (λf x.f x) (c (λf x.x) n)", shape: "list(nat)", value: []interface{}{1, 0}},
+		{input: "λc n.n", shape: "list", value: []interface{}{}},
+		{input: "λn c.c a (λn c.c (b d) (λn c.n))", shape: "scott-list", value: []interface{}{"a", "b d"}},
+		{input: "λp.p (λf x.f x) (λt f.t)", shape: "pair(nat,bool)", value: Pair{First: 1, Second: true}},
+		{input: "λp.p a (λc n.c (λt f.f) n)", shape: "pair(term,list(bool))", value: Pair{First: "a", Second: []interface{}{false}}},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		shape, err := ParseShape(test.shape)
+		res, err := parser.Decode(ast, shape)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.DeepEqual(t, res, test.value)
+	}
+}
+
+func happyFlowDecodeScottNatAndSum(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	var tests = []struct {
+		input string
+		shape string
+		value interface{}
+	}{
+		{input: "λz s.s (λz s.s (λz s.z))", shape: "scott-nat", value: 2},
+		{input: "λz s.z", shape: "scott-nat", value: 0},
+		{input: "λl r.r (λt f.t)", shape: "sum(nat,bool)", value: Sum{Tag: 1, Value: true}},
+		{input: "λl r.l (λf x.f x)", shape: "sum(nat,bool)", value: Sum{Tag: 0, Value: 1}},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		shape, err := ParseShape(test.shape)
+		res, err := parser.Decode(ast, shape)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.DeepEqual(t, res, test.value)
+	}
+}
+
+func happyFlowDecodeBareShapes(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	var tests = []struct {
+		input string
+		shape Shape
+		value interface{}
+	}{
+		{input: "λc n.c a (c (b d) n)", shape: Shape{Kind: LIST}, value: []interface{}{"a", "b d"}},
+		{input: "λn c.c a (λn c.n)", shape: Shape{Kind: SCOTT_LIST}, value: []interface{}{"a"}},
+		{input: "λp.p a b", shape: Shape{Kind: PAIR}, value: Pair{First: "a", Second: "b"}},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		res, err := parser.Decode(ast, test.shape)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.DeepEqual(t, res, test.value)
+	}
+}
+
+func happyFlowParseShapes(t *testing.T) {
+	// act
+	list, listErr := ParseShape("list")
+	pair, pairErr := ParseShape("pair(nat, list(bool))")
+
+	// assert
+	assert.Equal(t, listErr, nil)
+	assert.Equal(t, list.String(), "list(term)")
+	assert.Equal(t, pairErr, nil)
+	assert.Equal(t, pair.String(), "pair(nat,list(bool))")
+	assert.Equal(t, Pair{First: 1, Second: []interface{}{true}}.String(), "(1, [true])")
+}
+
+func negativeFlowParseUnknownShapes(t *testing.T) {
+	// act
+	_, unknownErr := ParseShape("tree")
+	_, arityErr := ParseShape("pair(nat)")
+	_, bracketErr := ParseShape("list(nat")
+	_, trailingErr := ParseShape("nat)")
+	_, sumErr := ParseShape("sum")
+
+	// assert
+	assert.Equal(t, unknownErr.Error(), `unknown shape "tree"`)
+	assert.Equal(t, arityErr.Error(), "pair takes 2 shapes, not 1")
+	assert.Equal(t, bracketErr.Error(), "missing ) in shape list(nat)")
+	assert.Equal(t, trailingErr.Error(), `unexpected ")" after shape`)
+	assert.Equal(t, sumErr.Error(), "sum takes the shapes of its alternatives")
+}
+
+func negativeFlowDecodeMismatch(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("λf x.f (f y)")
+	ast, _ := parser.Parse(tk)
+	_, natErr := parser.Decode(ast, Shape{Kind: NAT})
+	tk, _ = analyzer.Tokenize("λc n.c n n")
+	ast, _ = parser.Parse(tk)
+	_, listErr := parser.Decode(ast, Shape{Kind: LIST, Elems: []Shape{{Kind: RAW}}})
+
+	// assert
+	decodingErr, ok := natErr.(*DecodingError)
+	assert.Assert(t, ok)
+	assert.Equal(t, decodingErr.Shape.Kind, NAT)
+	assert.Equal(t, natErr.Error(), "could not decode λf.λx.f (f y) as nat")
+	assert.Equal(t, listErr.Error(), "could not decode λc.λn.c n n as list(term)")
+}
@@ -0,0 +1,278 @@
+package syntactical_analyzer
+
+import (
+	"fmt"
+	"math-parser/pkg/entity"
+	"sort"
+)
+
+// Definition binds Name to Term, read from a line name params = term of a definitions file.
+type Definition struct {
+	Name string
+	Term entity.Term
+	Span entity.Span
+}
+
+// Definitions are the definitions of a file in the order they were written. A definition may
+// refer to itself and to the others, before or after it: a name stands for its last definition
+// before the reference, or else for its first one after, so a definition hides an earlier one of
+// its name from the definitions that follow.
+type Definitions []Definition
+
+// ParseDefinitions reads a definitions file. A definition starts with a name at the column of the
+// first token, or left of it, and goes on up to the next one, so a long term may continue on
+// indented lines. Like ParseTree it recovers from syntax errors and reports all of them.
+func (l *lL1PredictableParser) ParseDefinitions(t []entity.Token) (Definitions, error) {
+	var res Definitions
+	var diagnostics entity.Diagnostics
+	for _, tokens := range splitDefinitions(t) {
+		tree, err := l.parseTree(tokens, entity.DEFINITION)
+		if tree == nil {
+			return nil, err
+		}
+		if err != nil {
+			diagnostics = append(diagnostics, l.diagnostics...)
+			continue
+		}
+		definition, err := lowering{rightAssociative: l.config.RightAssociative}.definition(tree.Root())
+		if err != nil {
+			return nil, err
+		}
+		if !l.config.KeepLet {
+			definition.Term = desugar(definition.Term)
+		}
+		res = append(res, definition)
+	}
+	if len(diagnostics) != 0 {
+		return res, diagnostics
+	}
+	l.logging.Debugf("parsed %d definitions", len(res))
+	return res, nil
+}
+
+// splitDefinitions cuts t before every token that is not right of the first one.
+func splitDefinitions(t []entity.Token) [][]entity.Token {
+	var res [][]entity.Token
+	for i, token := range t {
+		if i == 0 || token.Span.Start.Column <= t[0].Span.Start.Column {
+			res = append(res, nil)
+		}
+		res[len(res)-1] = append(res[len(res)-1], token)
+	}
+	return res
+}
+
+// Expand replaces the names of the definitions free in ast with their terms.
+func (d Definitions) Expand(ast entity.Ast) entity.Ast {
+	return entity.NewAst(expand(ast.Term(), d.closed()))
+}
+
+// Check reports the references of the definitions to names defined nowhere, primitives aside, and
+// to definitions that refer back to the one they are in, which Expand leaves unexpanded since only
+// a definition referring to itself directly is tied with the fixed-point combinator.
+func (d Definitions) Check() error {
+	var diagnostics entity.Diagnostics
+	for i, definition := range d {
+		for _, v := range freeOccurrences(definition.Term) {
+			if v.Name == definition.Name {
+				continue
+			}
+			j, ok := d.target(i, v.Name)
+			switch {
+			case !ok && !isPrimitive(v.Name):
+				diagnostics = append(diagnostics, &entity.Diagnostic{
+					Severity: entity.ERROR,
+					Code:     entity.UNDEFINED_NAME,
+					Span:     v.Span,
+					Message:  fmt.Sprintf("undefined name %s", v.Name),
+				})
+			case ok && d.reaches(j, i, map[int]bool{}):
+				diagnostics = append(diagnostics, &entity.Diagnostic{
+					Severity: entity.ERROR,
+					Code:     entity.CYCLIC_DEFINITION,
+					Span:     v.Span,
+					Message:  fmt.Sprintf("%s refers back to %s through %s", definition.Name, definition.Name, v.Name),
+				})
+			}
+		}
+	}
+	if len(diagnostics) != 0 {
+		return diagnostics
+	}
+	return nil
+}
+
+// target returns the index of the definition a reference to name in the definition i stands for.
+func (d Definitions) target(i int, name string) (int, bool) {
+	for j := i - 1; j >= 0; j-- {
+		if d[j].Name == name {
+			return j, true
+		}
+	}
+	for j := i + 1; j < len(d); j++ {
+		if d[j].Name == name {
+			return j, true
+		}
+	}
+	return 0, false
+}
+
+// reaches tells whether the definition i refers to the definition j, directly or through others.
+func (d Definitions) reaches(i int, j int, seen map[int]bool) bool {
+	if i == j {
+		return true
+	}
+	seen[i] = true
+	for name := range freeVariables(d[i].Term) {
+		if k, ok := d.target(i, name); ok && name != d[i].Name && !seen[k] && d.reaches(k, j, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// closed returns the term of every name, its last definition, where the definitions it refers to
+// are expanded in turn and a reference to itself is tied with the fixed-point combinator. Some of
+// the references between definitions that refer to each other, which Check reports, stay free.
+func (d Definitions) closed() map[string]entity.Term {
+	terms := make([]entity.Term, len(d))
+	resolving := make([]bool, len(d))
+	var resolve func(i int) entity.Term
+	resolve = func(i int) entity.Term {
+		if terms[i] != nil || resolving[i] {
+			return terms[i]
+		}
+		resolving[i] = true
+		references := map[string]entity.Term{}
+		for name := range freeVariables(d[i].Term) {
+			if j, ok := d.target(i, name); ok && name != d[i].Name {
+				if term := resolve(j); term != nil {
+					references[name] = term
+				}
+			}
+		}
+		terms[i] = recursive(d[i].Name, expand(d[i].Term, references))
+		resolving[i] = false
+		return terms[i]
+	}
+	res := map[string]entity.Term{}
+	for i, definition := range d {
+		res[definition.Name] = resolve(i)
+	}
+	return res
+}
+
+// freeOccurrences returns the occurrences of the variables of t that are not bound in it, in the
+// order they are written.
+func freeOccurrences(t entity.Term) []*entity.Var {
+	var res []*entity.Var
+	var walk func(entity.Term, map[string]int)
+	walk = func(t entity.Term, bound map[string]int) {
+		switch t := t.(type) {
+		case *entity.Var:
+			if bound[t.Name] == 0 {
+				res = append(res, t)
+			}
+		case *entity.Abs:
+			bound[t.Param]++
+			walk(t.Body, bound)
+			bound[t.Param]--
+		case *entity.App:
+			walk(t.Fun, bound)
+			walk(t.Arg, bound)
+		case *entity.Let:
+			if !t.Recursive {
+				walk(t.Value, bound)
+			}
+			bound[t.Name]++
+			if t.Recursive {
+				walk(t.Value, bound)
+			}
+			walk(t.Body, bound)
+			bound[t.Name]--
+		}
+	}
+	walk(t, map[string]int{})
+	return res
+}
+
+// expand substitutes terms for the free variables of t they name.
+func expand(t entity.Term, terms map[string]entity.Term) entity.Term {
+	var names []string
+	for name := range freeVariables(t) {
+		if _, ok := terms[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t = substitute(t, name, terms[name])
+	}
+	return t
+}
+
+// NewReferenceStrategy reduces with strategy and keeps the names of the definitions as references:
+// a name is replaced with its term, in a δ step, only once it is applied and strategy has no
+// redex left, so the names that are never applied remain in the result.
+func NewReferenceStrategy(strategy ReductionStrategy, definitions Definitions) ReductionStrategy {
+	terms := map[string]entity.Term{}
+	for _, definition := range definitions {
+		terms[definition.Name] = definition.Term
+	}
+	return &referenceStrategy{strategy: strategy, terms: terms}
+}
+
+type referenceStrategy struct {
+	strategy ReductionStrategy
+	terms    map[string]entity.Term
+}
+
+func (s *referenceStrategy) Name() string {
+	return s.strategy.Name()
+}
+
+func (s *referenceStrategy) Step(t entity.Term) (entity.Term, *Redex, error) {
+	res, redex, err := s.strategy.Step(t)
+	if err != nil || redex != nil {
+		return res, redex, err
+	}
+	res, redex = s.unfold(res, Position{}, map[string]int{})
+	return res, redex, nil
+}
+
+// unfold replaces the leftmost outermost applied reference of t, unless a binder around it
+// would capture a free variable, or a reference, of its term.
+func (s *referenceStrategy) unfold(t entity.Term, pos Position, bound map[string]int) (entity.Term, *Redex) {
+	switch t := t.(type) {
+	case *entity.Abs:
+		bound[t.Param]++
+		body, redex := s.unfold(t.Body, pos.Child(0), bound)
+		bound[t.Param]--
+		if redex == nil {
+			return t, nil
+		}
+		return t.Rebuild(t.Param, body), redex
+	case *entity.App:
+		if v, ok := t.Fun.(*entity.Var); ok && bound[v.Name] == 0 {
+			if term, ok := s.terms[v.Name]; ok && !captured(term, bound) {
+				return t.Rebuild(term, t.Arg), &Redex{Position: pos.Child(0), Rule: DELTA_RULE, Span: v.Span}
+			}
+		}
+		if fun, redex := s.unfold(t.Fun, pos.Child(0), bound); redex != nil {
+			return t.Rebuild(fun, t.Arg), redex
+		}
+		if arg, redex := s.unfold(t.Arg, pos.Child(1), bound); redex != nil {
+			return t.Rebuild(t.Fun, arg), redex
+		}
+	}
+	return t, nil
+}
+
+func captured(t entity.Term, bound map[string]int) bool {
+	for name := range freeVariables(t) {
+		if bound[name] != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+const booleans = `-- Church booleans
+true = λx y.x
+false = λx y.y
+not b =
+  b false true
+`
+
+func TestLL1PredictableParser_Definitions(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Parse definitions file",
+			scenario: happyFlowParseDefinitions,
+		},
+		{
+			name:     "Happy flow. Expand definitions",
+			scenario: happyFlowExpandDefinitions,
+		},
+		{
+			name:     "Happy flow. Expand recursive definition",
+			scenario: happyFlowExpandRecursiveDefinition,
+		},
+		{
+			name:     "Happy flow. Expand definitions under kept let bindings",
+			scenario: happyFlowExpandDefinitionsUnderLet,
+		},
+		{
+			name:     "Happy flow. Keep definitions as references",
+			scenario: happyFlowKeepDefinitionsAsReferences,
+		},
+		{
+			name:     "Happy flow. Expand definitions referring to later ones",
+			scenario: happyFlowExpandForwardReferences,
+		},
+		{
+			name:     "Negative flow. Report undefined and cyclic references",
+			scenario: negativeFlowCheckDefinitions,
+		},
+		{
+			name:     "Negative flow. Report errors of every definition",
+			scenario: negativeFlowReportDefinitionErrors,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowParseDefinitions(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize(booleans)
+	definitions, err := parser.ParseDefinitions(tk)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(definitions), 3)
+	assert.Equal(t, definitions[2].Name, "not")
+	assert.Equal(t, definitions[2].Span.String(), "4:1-5:15")
+	res, err := Print(entity.NewAst(definitions[2].Term), PrintOptions{Notation: Notation{Juxtaposition: true}})
+	assert.Equal(t, res, "λb.b false true")
+}
+
+func happyFlowExpandDefinitions(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	tk, _ := analyzer.Tokenize(booleans)
+	definitions, _ := parser.ParseDefinitions(tk)
+
+	// act
+	tk, _ = analyzer.Tokenize("not (not false) a b")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.Reduce(definitions.Expand(ast), NewNormalOrderStrategy())
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "b")
+}
+
+func happyFlowExpandRecursiveDefinition(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	tk, _ := analyzer.Tokenize(booleans + "first b = b a (first true)\n")
+	definitions, _ := parser.ParseDefinitions(tk)
+
+	// act
+	tk, _ = analyzer.Tokenize("first false")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.Reduce(definitions.Expand(ast), NewNormalOrderStrategy())
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "a")
+}
+
+func happyFlowExpandDefinitionsUnderLet(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true, KeepLet: true})
+	tk, _ := analyzer.Tokenize("const = λa.x\nnot = λb.b\n")
+	definitions, _ := parser.ParseDefinitions(tk)
+
+	// act
+	tk, _ = analyzer.Tokenize("let x = not in const x (let not = x in not)")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(definitions.Expand(ast))
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "let x' = λb.b in (λa.x) x' let not = x' in not")
+}
+
+func happyFlowKeepDefinitionsAsReferences(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	tk, _ := analyzer.Tokenize(booleans)
+	definitions, _ := parser.ParseDefinitions(tk)
+
+	// act
+	tk, _ = analyzer.Tokenize("λx.not (not true)")
+	ast, err := parser.Parse(tk)
+	steps, err := parser.Trace(ast, NewReferenceStrategy(NewNormalOrderStrategy(), definitions))
+	ast, err = parser.Reduce(ast, NewReferenceStrategy(NewNormalOrderStrategy(), definitions))
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "λx.true")
+	assert.Equal(t, steps[1].Redex.Rule, DELTA_RULE)
+	assert.Equal(t, steps[1].Redex.Position.String(), "0.0")
+	assert.Equal(t, steps[1].Redex.Span.String(), "1:4-1:7")
+}
+
+func happyFlowExpandForwardReferences(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	tk, _ := analyzer.Tokenize("b = a\na = λx.x\nid = b\nb = a a")
+	definitions, _ := parser.ParseDefinitions(tk)
+
+	// act
+	tk, _ = analyzer.Tokenize("id b")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(definitions.Expand(ast))
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, definitions.Check(), nil)
+	assert.Equal(t, res, "(λx.x) ((λx.x) λx.x)")
+}
+
+func negativeFlowCheckDefinitions(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	tk, _ := analyzer.Tokenize("even n = n odd\nodd n = even n\nloop = loop\nk = λx.y")
+	definitions, _ := parser.ParseDefinitions(tk)
+
+	// act
+	err := definitions.Check()
+
+	// assert
+	diagnostics, ok := err.(entity.Diagnostics)
+	assert.Assert(t, ok)
+	assert.Equal(t, diagnostics[0].Code, entity.CYCLIC_DEFINITION)
+	assert.Equal(t, diagnostics[2].Code, entity.UNDEFINED_NAME)
+	assert.Equal(t, err.Error(), ""+
+		"1:12: error[P004]: even refers back to even through odd\n"+
+		"2:9: error[P004]: odd refers back to odd through even\n"+
+		"4:8: error[P003]: undefined name y")
+}
+
+func negativeFlowReportDefinitionErrors(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("id = λx.x\nk = λx.\nc x y = x y\nz λ")
+	definitions, err := parser.ParseDefinitions(tk)
+
+	// assert
+	assert.Equal(t, err.Error(), ""+
+		"2:8: error[P002]: expected variable, λ, (, let or letrec, found end of input\n"+
+		"4:3: error[P001]: expected variable or =, found λ\n"+
+		"4:4: error[P002]: expected variable, found end of input")
+	assert.Equal(t, len(definitions), 2)
+	assert.Equal(t, definitions[1].Name, "c")
+}
@@ -0,0 +1,294 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Diagnostics(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Negative flow. Report unclosed bracket",
+			scenario: negativeFlowReportUnclosedBracket,
+		},
+		{
+			name:     "Negative flow. Report missing parameter",
+			scenario: negativeFlowReportMissingParameter,
+		},
+		{
+			name:     "Negative flow. Report missing argument",
+			scenario: negativeFlowReportMissingArgument,
+		},
+		{
+			name:     "Negative flow. Render diagnostic on its line",
+			scenario: negativeFlowRenderDiagnostic,
+		},
+		{
+			name:     "Negative flow. Recover and report every syntax error",
+			scenario: negativeFlowRecoverEverySyntaxError,
+		},
+		{
+			name:     "Negative flow. Recover by skipping unexpected tokens",
+			scenario: negativeFlowRecoverBySkippingTokens,
+		},
+		{
+			name:     "Negative flow. Expect closing bracket and in only when open",
+			scenario: negativeFlowExpectClosingTokensWhenOpen,
+		},
+		{
+			name:     "Negative flow. Recover missing closing bracket",
+			scenario: negativeFlowRecoverMissingClosingBracket,
+		},
+		{
+			name:     "Negative flow. Reject trailing bracket",
+			scenario: negativeFlowRejectTrailingBracket,
+		},
+		{
+			name:     "Negative flow. Reject trailing input after abstraction",
+			scenario: negativeFlowRejectTrailingInputAfterAbstraction,
+		},
+		{
+			name:     "Happy flow. Ignore trailing input in lenient mode",
+			scenario: happyFlowIgnoreTrailingInputInLenientMode,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func negativeFlowReportUnclosedBracket(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.(x_y")
+	_, err := parser.Parse(tk)
+
+	// assert
+	diagnostics, ok := err.(entity.Diagnostics)
+	assert.Assert(t, ok)
+	assert.Equal(t, len(diagnostics), 1)
+	diagnostic := diagnostics[0]
+	assert.Equal(t, diagnostic.Code, entity.UNEXPECTED_END_OF_INPUT)
+	assert.DeepEqual(t, diagnostic.Expected, []entity.Tag{entity.APPLICATION, entity.RIGHT_BRACKET})
+	assert.Equal(t, diagnostic.Actual.Tag, entity.EPSILON)
+	assert.Equal(t, diagnostic.Span.String(), "1:8-1:8")
+	assert.Equal(t, err.Error(), "1:8: error[P002]: expected _ or ), found end of input")
+}
+
+func negativeFlowReportMissingParameter(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λ.x")
+	_, err := parser.Parse(tk)
+
+	// assert
+	diagnostics, ok := err.(entity.Diagnostics)
+	assert.Assert(t, ok)
+	assert.Equal(t, len(diagnostics), 1)
+	diagnostic := diagnostics[0]
+	assert.Equal(t, diagnostic.Code, entity.UNEXPECTED_TOKEN)
+	assert.DeepEqual(t, diagnostic.Expected, []entity.Tag{entity.VARIABLE})
+	assert.Equal(t, err.Error(), "1:2: error[P001]: expected variable, found .")
+}
+
+func negativeFlowReportMissingArgument(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("f_)")
+	_, err := parser.Parse(tk)
+
+	// assert
+	diagnostics, ok := err.(entity.Diagnostics)
+	assert.Assert(t, ok)
+	assert.Equal(t, len(diagnostics), 1)
+	diagnostic := diagnostics[0]
+	assert.DeepEqual(t, diagnostic.Expected, underscoreGrammar.first(entity.TERM))
+	assert.Equal(t, err.Error(), "1:3: error[P001]: expected variable, λ, (, let or letrec, found )")
+}
+
+func negativeFlowRenderDiagnostic(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+	source := "-- twice\nλf.λx.f_(f_x"
+
+	// act
+	tk, _ := analyzer.Tokenize(source)
+	_, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err.(entity.Diagnostics)[0].Render(source), ""+
+		"error[P002]: expected _ or ), found end of input\n"+
+		" --> 2:13\n"+
+		"  |\n"+
+		"2 | λf.λx.f_(f_x\n"+
+		"  |             ^\n")
+}
+
+func negativeFlowRecoverEverySyntaxError(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λ.x)_(y_)")
+	ast, err := parser.Parse(tk)
+
+	// assert
+	diagnostics, ok := err.(entity.Diagnostics)
+	assert.Assert(t, ok)
+	assert.Equal(t, err.Error(), ""+
+		"1:3: error[P001]: expected variable, found .\n"+
+		"1:10: error[P001]: expected variable, λ, (, let or letrec, found )")
+	assert.Equal(t, len(diagnostics), 2)
+	app := ast.Term().(*entity.App)
+	assert.Equal(t, app.Fun.(*entity.Error).Span.String(), "1:2-1:5")
+	assert.Equal(t, app.Arg.(*entity.App).Fun.(*entity.Var).Name, "y")
+	assert.Equal(t, app.Arg.(*entity.App).Arg.(*entity.Error).Span.String(), "1:10-1:10")
+}
+
+func negativeFlowRecoverBySkippingTokens(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("f_x λy.y_z")
+	ast, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err.Error(), "1:5: error[P001]: expected _ or end of input, found λ")
+	res, err := parser.Unparse(ast)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "((f_x)_z)")
+}
+
+func negativeFlowExpectClosingTokensWhenOpen(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+	juxtaposition := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("(f_x λy.y)")
+	_, bracketErr := parser.Parse(tk)
+	tk, _ = analyzer.Tokenize("let a = f_x λy.y in a")
+	_, letErr := parser.Parse(tk)
+	tk, _ = analyzer.Tokenize("let a = (f) in a_x λy.y")
+	_, closedErr := parser.Parse(tk)
+	tk, _ = analyzer.Tokenize("let x = y z")
+	_, letEndErr := parser.Parse(tk)
+	tk, _ = analyzer.Tokenize("λx.(x y")
+	_, bracketEndErr := juxtaposition.Parse(tk)
+
+	// assert
+	assert.Equal(t, bracketErr.Error(), "1:6: error[P001]: expected _ or ), found λ")
+	assert.Equal(t, letErr.Error(), "1:13: error[P001]: expected _ or in, found λ")
+	assert.Equal(t, closedErr.Error(), "1:20: error[P001]: expected _ or end of input, found λ")
+	assert.Equal(t, letEndErr.Error(), ""+
+		"1:11: error[P001]: expected _ or in, found variable z\n"+
+		"1:12: error[P002]: expected _ or in, found end of input")
+	assert.Equal(t, bracketEndErr.Error(), "1:8: error[P002]: expected variable, λ, (, ), let or letrec, found end of input")
+}
+
+func negativeFlowRecoverMissingClosingBracket(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.(x_y")
+	tree, err := parser.ParseTree(tk)
+	ast, _ := Lower(tree)
+	res, _ := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, len(err.(entity.Diagnostics)), 1)
+	assert.Equal(t, res, "(λx.(x_y))")
+}
+
+func negativeFlowRejectTrailingBracket(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("x)")
+	_, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err.Error(), "1:2: error[P001]: expected _ or end of input, found )")
+}
+
+func negativeFlowRejectTrailingInputAfterAbstraction(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.x)(")
+	_, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err.Error(), "1:5: error[P001]: expected _ or end of input, found )")
+}
+
+func happyFlowIgnoreTrailingInputInLenientMode(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Lenient: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("x)")
+	ast, err := parser.Parse(tk)
+	res, _ := parser.Unparse(ast)
+	tk, _ = analyzer.Tokenize("f_x λy.y")
+	prefix, prefixErr := parser.Parse(tk)
+	prefixRes, _ := parser.Unparse(prefix)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "x")
+	assert.Equal(t, prefixErr, nil)
+	assert.Equal(t, prefixRes, "(f_x)")
+}
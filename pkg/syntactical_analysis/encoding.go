@@ -0,0 +1,185 @@
+package syntactical_analyzer
+
+import (
+	"fmt"
+	"math-parser/pkg/entity"
+	"reflect"
+)
+
+// EncodingError reports a Go value that has no encoding of the expected shape.
+type EncodingError struct {
+	Shape Shape
+	Value interface{}
+}
+
+func (e *EncodingError) Error() string {
+	return fmt.Sprintf("cannot encode %v as %v", e.Value, e.Shape)
+}
+
+// Encode builds the term of the given shape that Decode reads value back from: an int for NAT
+// and SCOTT_NAT, a bool for BOOL, a slice for LIST and SCOTT_LIST, a Pair for PAIR, a Sum for SUM,
+// and an entity.Term or entity.Ast for RAW. The binders of the encoding never capture the free
+// variables of a RAW element.
+func Encode(value interface{}, shape Shape) (entity.Ast, error) {
+	res, err := encode(value, shape)
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewAst(res), nil
+}
+
+// Apply applies fun to args, one after the other, so that encoded values can be passed to a
+// parsed term.
+func Apply(fun entity.Ast, args ...entity.Ast) entity.Ast {
+	res := fun.Term()
+	for _, arg := range args {
+		res = entity.NewApp(res, arg.Term())
+	}
+	return entity.NewAst(res)
+}
+
+func encode(value interface{}, shape Shape) (entity.Term, error) {
+	fail := &EncodingError{Shape: shape, Value: value}
+	switch shape.Kind {
+	case NAT, SCOTT_NAT:
+		n, ok := value.(int)
+		if !ok || n < 0 {
+			return nil, fail
+		}
+		if shape.Kind == NAT {
+			return encodeNat(n), nil
+		}
+		return encodeScottNat(n), nil
+	case BOOL:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fail
+		}
+		res := "f"
+		if b {
+			res = "t"
+		}
+		return abstract([]string{"t", "f"}, entity.NewVar(res)), nil
+	case LIST, SCOTT_LIST:
+		v := reflect.ValueOf(value)
+		if !v.IsValid() || v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return nil, fail
+		}
+		elems := make([]entity.Term, v.Len())
+		for i := range elems {
+			var err error
+			if elems[i], err = encode(v.Index(i).Interface(), shape.elem(0)); err != nil {
+				return nil, err
+			}
+		}
+		if shape.Kind == LIST {
+			return encodeList(elems), nil
+		}
+		return encodeScottList(elems), nil
+	case PAIR:
+		p, ok := value.(Pair)
+		if !ok {
+			return nil, fail
+		}
+		x, err := encode(p.First, shape.elem(0))
+		if err != nil {
+			return nil, err
+		}
+		y, err := encode(p.Second, shape.elem(1))
+		if err != nil {
+			return nil, err
+		}
+		params := fresh([]string{"f"}, x, y)
+		return abstract(params, entity.NewApp(entity.NewApp(entity.NewVar(params[0]), x), y)), nil
+	case SUM:
+		s, ok := value.(Sum)
+		if !ok || s.Tag < 0 || s.Tag >= len(shape.Elems) {
+			return nil, fail
+		}
+		x, err := encode(s.Value, shape.Elems[s.Tag])
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(shape.Elems))
+		for i := range names {
+			names[i] = fmt.Sprintf("c%d", i+1)
+		}
+		params := fresh(names, x)
+		return abstract(params, entity.NewApp(entity.NewVar(params[s.Tag]), x)), nil
+	case RAW:
+		switch t := value.(type) {
+		case entity.Ast:
+			return t.Term(), nil
+		case entity.Term:
+			return t, nil
+		}
+		return nil, fail
+	default:
+		return nil, fail
+	}
+}
+
+// encodeNat returns the Church numeral λf.λx.f_(…(f_x)) of n.
+func encodeNat(n int) entity.Term {
+	var body entity.Term = entity.NewVar("x")
+	for i := 0; i < n; i++ {
+		body = entity.NewApp(entity.NewVar("f"), body)
+	}
+	return abstract([]string{"f", "x"}, body)
+}
+
+// encodeScottNat returns the Scott numeral of n, λz.λs.s_m for the numeral m of n-1.
+func encodeScottNat(n int) entity.Term {
+	res := abstract([]string{"z", "s"}, entity.NewVar("z"))
+	for i := 0; i < n; i++ {
+		res = abstract([]string{"z", "s"}, entity.NewApp(entity.NewVar("s"), res))
+	}
+	return res
+}
+
+// encodeList returns the Church list λc.λn.c_x1_(c_x2_(…n)) of elems.
+func encodeList(elems []entity.Term) entity.Term {
+	params := fresh([]string{"c", "n"}, elems...)
+	var res entity.Term = entity.NewVar(params[1])
+	for i := len(elems) - 1; i >= 0; i-- {
+		res = entity.NewApp(entity.NewApp(entity.NewVar(params[0]), elems[i]), res)
+	}
+	return abstract(params, res)
+}
+
+// encodeScottList returns the Scott list of elems, λn.λc.n when empty, λn.λc.c_x_xs otherwise.
+func encodeScottList(elems []entity.Term) entity.Term {
+	res := abstract([]string{"n", "c"}, entity.NewVar("n"))
+	for i := len(elems) - 1; i >= 0; i-- {
+		params := fresh([]string{"n", "c"}, elems[i])
+		res = abstract(params, entity.NewApp(entity.NewApp(entity.NewVar(params[1]), elems[i]), res))
+	}
+	return res
+}
+
+// fresh renames the names free in one of terms.
+func fresh(names []string, terms ...entity.Term) []string {
+	used := map[string]bool{}
+	for _, t := range terms {
+		for name := range freeVariables(t) {
+			used[name] = true
+		}
+	}
+	res := make([]string, len(names))
+	for i, name := range names {
+		if used[name] {
+			name = freshVariable(name, used)
+		}
+		res[i] = name
+		used[name] = true
+	}
+	return res
+}
+
+// abstract nests one abstraction per parameter around body.
+func abstract(params []string, body entity.Term) entity.Term {
+	for i := len(params) - 1; i >= 0; i-- {
+		body = entity.NewAbs(params[i], body)
+	}
+	return body
+}
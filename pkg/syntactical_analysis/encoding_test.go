@@ -0,0 +1,131 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Decode encoded values of every shape",
+			scenario: happyFlowEncodeRoundTrip,
+		},
+		{
+			name:     "Happy flow. Apply parsed term to encoded arguments",
+			scenario: happyFlowEncodeApplyParsedTerm,
+		},
+		{
+			name:     "Happy flow. Rename binders free in raw elements",
+			scenario: happyFlowEncodeAvoidCapture,
+		},
+		{
+			name:     "Negative flow. Reject values without encoding",
+			scenario: negativeFlowEncodeMismatch,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowEncodeRoundTrip(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	var tests = []struct {
+		value interface{}
+		shape string
+		term  string
+		res   interface{}
+	}{
+		{value: 3, shape: "nat", term: "λf.λx.f (f (f x))", res: 3},
+		{value: 2, shape: "scott-nat", term: "λz.λs.s λz.λs.s λz.λs.z", res: 2},
+		{value: false, shape: "bool", term: "λt.λf.f", res: false},
+		{value: []int{1, 0}, shape: "list(nat)", term: "λc.λn.c (λf.λx.f x) (c (λf.λx.x) n)", res: []interface{}{1, 0}},
+		{value: []bool{true}, shape: "scott-list(bool)", term: "λn.λc.c (λt.λf.t) λn.λc.n", res: []interface{}{true}},
+		{value: Pair{First: 1, Second: true}, shape: "pair(nat,bool)", term: "λf.f (λf.λx.f x) λt.λf.t", res: Pair{First: 1, Second: true}},
+		{value: Sum{Tag: 1, Value: true}, shape: "sum(nat,bool)", term: "λc1.λc2.c2 λt.λf.t", res: Sum{Tag: 1, Value: true}},
+		{value: entity.NewVar("a"), shape: "term", term: "a", res: "a"},
+	}
+
+	for _, test := range tests {
+		// act
+		shape, err := ParseShape(test.shape)
+		ast, err := Encode(test.value, shape)
+		term, err := parser.Unparse(ast)
+		res, err := parser.Decode(ast, shape)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.Equal(t, term, test.term)
+		assert.DeepEqual(t, res, test.res)
+	}
+}
+
+func happyFlowEncodeApplyParsedTerm(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	nat := Shape{Kind: NAT}
+
+	// act
+	tk, _ := analyzer.Tokenize("λm n f x.m f (n f x)")
+	plus, err := parser.Parse(tk)
+	two, err := Encode(2, nat)
+	three, err := Encode(3, nat)
+	normal, err := parser.Reduce(Apply(plus, two, three), NewNormalOrderStrategy())
+	res, err := parser.Decode(normal, nat)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, 5)
+}
+
+func happyFlowEncodeAvoidCapture(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	list, err := Encode([]entity.Term{entity.NewVar("c")}, Shape{Kind: LIST, Elems: []Shape{{Kind: RAW}}})
+	listRes, err := parser.Unparse(list)
+	pair, err := Encode(Pair{First: entity.NewVar("f"), Second: 0}, Shape{Kind: PAIR, Elems: []Shape{{Kind: RAW}, {Kind: NAT}}})
+	pairRes, err := parser.Unparse(pair)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, listRes, "λc'.λn.c' c n")
+	assert.Equal(t, pairRes, "λf'.f' f λf.λx.x")
+}
+
+func negativeFlowEncodeMismatch(t *testing.T) {
+	// arrange
+	sum := Shape{Kind: SUM, Elems: []Shape{{Kind: NAT}}}
+
+	// act
+	_, natErr := Encode(-1, Shape{Kind: NAT})
+	_, boolErr := Encode("x", Shape{Kind: BOOL})
+	_, sumErr := Encode(Sum{Tag: 1, Value: 0}, sum)
+	_, elemErr := Encode([]interface{}{1, true}, Shape{Kind: LIST, Elems: []Shape{{Kind: NAT}}})
+
+	// assert
+	encodingErr, ok := natErr.(*EncodingError)
+	assert.Assert(t, ok)
+	assert.Equal(t, encodingErr.Shape.Kind, NAT)
+	assert.Equal(t, natErr.Error(), "cannot encode -1 as nat")
+	assert.Equal(t, boolErr.Error(), "cannot encode x as bool")
+	assert.Equal(t, sumErr.Error(), "cannot encode #1 0 as sum(nat)")
+	assert.Equal(t, elemErr.Error(), "cannot encode true as nat")
+}
@@ -0,0 +1,53 @@
+package syntactical_analyzer
+
+import (
+	"math-parser/pkg/entity"
+)
+
+// etaContract rewrites the abstraction λx.M_x to M, provided x is not free in M.
+func etaContract(abs *entity.Abs) (entity.Term, bool) {
+	app, ok := abs.Body.(*entity.App)
+	if !ok {
+		return abs, false
+	}
+	if arg, ok := app.Arg.(*entity.Var); !ok || arg.Name != abs.Param || freeVariables(app.Fun)[abs.Param] {
+		return abs, false
+	}
+	return app.Fun, true
+}
+
+// etaExpand rewrites t to λx.t_x, where x is not free in t.
+func etaExpand(t entity.Term) entity.Term {
+	param := "x"
+	if fv := freeVariables(t); fv[param] {
+		param = freshVariable(param, fv)
+	}
+	return entity.NewAbs(param, entity.NewApp(t, entity.NewVar(param)))
+}
+
+// EtaReduce contracts eta-redexes until the term is in η-normal form.
+func (l *lL1PredictableParser) EtaReduce(ast entity.Ast) (entity.Ast, error) {
+	return l.Normalize(l.ctx, ast, NormalizationOptions{Strategy: NewEtaStrategy()})
+}
+
+// EtaExpand abstracts the whole term over a fresh variable it is applied to.
+func (l *lL1PredictableParser) EtaExpand(ast entity.Ast) (entity.Ast, error) {
+	ast = entity.NewAst(etaExpand(ast.Term()))
+
+	l.logging.Debugf("ast after eta-expansion:\n%s", ast.Visualize())
+	return ast, nil
+}
+
+// BetaEtaEquivalent reports whether a and b have the same βη-normal form up to the names of their
+// bound variables. It fails like Reduce when either of them has no normal form within the default limits.
+func (l *lL1PredictableParser) BetaEtaEquivalent(a entity.Ast, b entity.Ast) (bool, error) {
+	normalA, err := l.Reduce(a, NewBetaEtaStrategy())
+	if err != nil {
+		return false, err
+	}
+	normalB, err := l.Reduce(b, NewBetaEtaStrategy())
+	if err != nil {
+		return false, err
+	}
+	return AlphaEquivalent(normalA, normalB), nil
+}
@@ -0,0 +1,202 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Eta(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Eta reduction",
+			scenario: happyFlowEtaReduction,
+		},
+		{
+			name:     "Happy flow. Eta reduction keeps abstraction over free variable",
+			scenario: happyFlowEtaReductionKeepsAbstractionOverFreeVariable,
+		},
+		{
+			name:     "Happy flow. Eta reduction under abstraction",
+			scenario: happyFlowEtaReductionUnderAbstraction,
+		},
+		{
+			name:     "Happy flow. Eta expansion",
+			scenario: happyFlowEtaExpansion,
+		},
+		{
+			name:     "Happy flow. Eta expansion avoids free variable",
+			scenario: happyFlowEtaExpansionAvoidsFreeVariable,
+		},
+		{
+			name:     "Happy flow. Beta-eta normal form",
+			scenario: happyFlowBetaEtaNormalForm,
+		},
+		{
+			name:     "Happy flow. Beta-eta equivalence",
+			scenario: happyFlowBetaEtaEquivalence,
+		},
+		{
+			name:     "Negative flow. Beta-eta equivalence without normal form",
+			scenario: negativeFlowBetaEtaEquivalenceWithoutNormalForm,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowEtaReduction(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.(f_y)_x")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.EtaReduce(ast)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(f_y)")
+}
+
+func happyFlowEtaReductionKeepsAbstractionOverFreeVariable(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.(f_x)_x")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.EtaReduce(ast)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx.((f_x)_x))")
+}
+
+func happyFlowEtaReductionUnderAbstraction(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λf.λx.f_x")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.EtaReduce(ast)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λf.f)")
+}
+
+func happyFlowEtaExpansion(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("f")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.EtaExpand(ast)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx.(f_x))")
+}
+
+func happyFlowEtaExpansionAvoidsFreeVariable(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("f_x")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.EtaExpand(ast)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx'.((f_x)_x'))")
+}
+
+func happyFlowBetaEtaNormalForm(t *testing.T) {
+	// act
+	res, err := reduceExpression("λx.(λy.λz.y_z)_f_x", BETA_ETA)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "f")
+}
+
+func happyFlowBetaEtaEquivalence(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+	var tests = []struct {
+		a          string
+		b          string
+		equivalent bool
+	}{
+		{a: "λx.f_x", b: "f", equivalent: true},
+		{a: "(λx.λy.x)_a", b: "λz.a", equivalent: true},
+		{a: "λx.(λy.λz.y_z)_f_x", b: "(λg.g)_f", equivalent: true},
+		{a: "λx.x", b: "λx.y", equivalent: false},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.a)
+		a, err := parser.Parse(tk)
+		tk, _ = analyzer.Tokenize(test.b)
+		b, err := parser.Parse(tk)
+		res, err := parser.BetaEtaEquivalent(a, b)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res, test.equivalent, test.a+" ≡ "+test.b)
+	}
+}
+
+func negativeFlowBetaEtaEquivalenceWithoutNormalForm(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x_x)_(λx.x_x)")
+	omega, _ := parser.Parse(tk)
+	res, err := parser.BetaEtaEquivalent(omega, omega)
+
+	// assert
+	_, ok := err.(*NormalizationError)
+	assert.Assert(t, ok)
+	assert.Assert(t, !res)
+}
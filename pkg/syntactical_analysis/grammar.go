@@ -0,0 +1,162 @@
+package syntactical_analyzer
+
+import (
+	"math-parser/pkg/entity"
+	"sort"
+)
+
+// grammar is an LL(1) table, giving the production of each non-terminal by lookahead, together
+// with the FOLLOW set of each non-terminal. EPSILON stands for the empty word in the table and
+// for the end of input in FOLLOW.
+//
+// Both grammars read the parameters of an abstraction with vs ⟶ ε | v a vs, so that λx y.M is
+// sugar for λx.λy.M, each parameter taking an optional type annotation a, where → associates to
+// the right
+//
+//	a ⟶ ε | : τ
+//	τ ⟶ v τs | ( τ ) τs
+//	τs ⟶ ε | → τ
+//
+// They share the let bindings, whose body extends as far right as possible
+// (and which may end an application in the juxtaposition grammar, like an abstraction), and the
+// definitions D of a definitions file
+//
+//	Λ ⟶ let v vs = Λ in Λ | letrec v vs = Λ in Λ
+//	D ⟶ v vs = Λ
+type grammar struct {
+	rules  map[entity.Tag]map[entity.Tag][]entity.Tag
+	follow map[entity.Tag][]entity.Tag
+}
+
+var parameters = map[entity.Tag][]entity.Tag{
+	entity.VARIABLE: {entity.VARIABLE, entity.ANNOTATION, entity.PARAMETERS},
+	entity.EPSILON:  {entity.EPSILON},
+}
+
+var annotation = map[entity.Tag][]entity.Tag{
+	entity.COLON:   {entity.COLON, entity.TYPE},
+	entity.EPSILON: {entity.EPSILON},
+}
+
+var types = map[entity.Tag][]entity.Tag{
+	entity.VARIABLE:     {entity.VARIABLE, entity.TYPES},
+	entity.LEFT_BRACKET: {entity.LEFT_BRACKET, entity.TYPE, entity.RIGHT_BRACKET, entity.TYPES},
+}
+
+var arrows = map[entity.Tag][]entity.Tag{
+	entity.ARROW:   {entity.ARROW, entity.TYPE},
+	entity.EPSILON: {entity.EPSILON},
+}
+
+// The types follow a parameter, and may be followed by the next one or the end of the parameters.
+var typeFollow = []entity.Tag{entity.VARIABLE, entity.ABSTRACTION, entity.RIGHT_BRACKET, entity.EQUALS}
+
+var definition = map[entity.Tag][]entity.Tag{
+	entity.VARIABLE: {entity.VARIABLE, entity.PARAMETERS, entity.EQUALS, entity.TERM},
+}
+
+var (
+	let    = []entity.Tag{entity.LET, entity.VARIABLE, entity.PARAMETERS, entity.EQUALS, entity.TERM, entity.IN, entity.TERM}
+	letrec = []entity.Tag{entity.LETREC, entity.VARIABLE, entity.PARAMETERS, entity.EQUALS, entity.TERM, entity.IN, entity.TERM}
+)
+
+// underscoreGrammar writes every application with _
+//
+//	Λ ⟶ v Λs | λ v a vs . Λ Λs | ( Λ ) Λs
+//	Λs ⟶ ε | _ Λ
+var underscoreGrammar = grammar{
+	rules: map[entity.Tag]map[entity.Tag][]entity.Tag{
+		entity.TERM: {
+			entity.VARIABLE:     {entity.VARIABLE, entity.TERMS},
+			entity.LAMBDA:       {entity.LAMBDA, entity.VARIABLE, entity.ANNOTATION, entity.PARAMETERS, entity.ABSTRACTION, entity.TERM, entity.TERMS},
+			entity.LEFT_BRACKET: {entity.LEFT_BRACKET, entity.TERM, entity.RIGHT_BRACKET, entity.TERMS},
+			entity.LET:          let,
+			entity.LETREC:       letrec,
+		},
+		entity.TERMS: {
+			entity.APPLICATION: {entity.APPLICATION, entity.TERM},
+			entity.EPSILON:     {entity.EPSILON},
+		},
+		entity.PARAMETERS: parameters,
+		entity.DEFINITION: definition,
+		entity.ANNOTATION: annotation,
+		entity.TYPE:       types,
+		entity.TYPES:      arrows,
+	},
+	follow: map[entity.Tag][]entity.Tag{
+		entity.TERM:       {entity.APPLICATION, entity.RIGHT_BRACKET, entity.IN, entity.EPSILON},
+		entity.TERMS:      {entity.APPLICATION, entity.RIGHT_BRACKET, entity.IN, entity.EPSILON},
+		entity.PARAMETERS: {entity.ABSTRACTION, entity.EQUALS},
+		entity.DEFINITION: {entity.EPSILON},
+		entity.ANNOTATION: {entity.VARIABLE, entity.ABSTRACTION, entity.EQUALS},
+		entity.TYPE:       typeFollow,
+		entity.TYPES:      typeFollow,
+	},
+}
+
+// juxtapositionGrammar writes an application as a sequence of terms, the last of which
+// may be an abstraction
+//
+//	Λ ⟶ v Λs | λ v a vs . Λ | ( Λ ) Λs
+//	Λs ⟶ ε | v Λs | λ v a vs . Λ | ( Λ ) Λs
+var juxtapositionGrammar = grammar{
+	rules: map[entity.Tag]map[entity.Tag][]entity.Tag{
+		entity.TERM: {
+			entity.VARIABLE:     {entity.VARIABLE, entity.TERMS},
+			entity.LAMBDA:       {entity.LAMBDA, entity.VARIABLE, entity.ANNOTATION, entity.PARAMETERS, entity.ABSTRACTION, entity.TERM},
+			entity.LEFT_BRACKET: {entity.LEFT_BRACKET, entity.TERM, entity.RIGHT_BRACKET, entity.TERMS},
+			entity.LET:          let,
+			entity.LETREC:       letrec,
+		},
+		entity.TERMS: {
+			entity.VARIABLE:     {entity.VARIABLE, entity.TERMS},
+			entity.LAMBDA:       {entity.LAMBDA, entity.VARIABLE, entity.ANNOTATION, entity.PARAMETERS, entity.ABSTRACTION, entity.TERM},
+			entity.LEFT_BRACKET: {entity.LEFT_BRACKET, entity.TERM, entity.RIGHT_BRACKET, entity.TERMS},
+			entity.LET:          let,
+			entity.LETREC:       letrec,
+			entity.EPSILON:      {entity.EPSILON},
+		},
+		entity.PARAMETERS: parameters,
+		entity.DEFINITION: definition,
+		entity.ANNOTATION: annotation,
+		entity.TYPE:       types,
+		entity.TYPES:      arrows,
+	},
+	follow: map[entity.Tag][]entity.Tag{
+		entity.TERM:       {entity.RIGHT_BRACKET, entity.IN, entity.EPSILON},
+		entity.TERMS:      {entity.RIGHT_BRACKET, entity.IN, entity.EPSILON},
+		entity.PARAMETERS: {entity.ABSTRACTION, entity.EQUALS},
+		entity.DEFINITION: {entity.EPSILON},
+		entity.ANNOTATION: {entity.VARIABLE, entity.ABSTRACTION, entity.EQUALS},
+		entity.TYPE:       typeFollow,
+		entity.TYPES:      typeFollow,
+	},
+}
+
+// first returns FIRST(nonTerminal), EPSILON standing for the empty word. Every production
+// starts with a terminal or is empty, so these are the lookaheads of the table.
+func (g grammar) first(nonTerminal entity.Tag) []entity.Tag {
+	var res []entity.Tag
+	for t := range g.rules[nonTerminal] {
+		res = append(res, t)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// lookaheads returns the terminals that can come first when symbol is expected: the terminal
+// itself, or FIRST of the non-terminal where the empty word is replaced with its FOLLOW.
+func (g grammar) lookaheads(symbol entity.Tag) []entity.Tag {
+	if entity.IsTerminal(symbol) {
+		return []entity.Tag{symbol}
+	}
+	var res []entity.Tag
+	for _, t := range g.first(symbol) {
+		if t == entity.EPSILON {
+			res = append(res, g.follow[symbol]...)
+		} else {
+			res = append(res, t)
+		}
+	}
+	return res
+}
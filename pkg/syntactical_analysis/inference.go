@@ -0,0 +1,274 @@
+package syntactical_analyzer
+
+import (
+	"fmt"
+	"math-parser/pkg/entity"
+)
+
+// TypeScheme is a type generalized over some of its variables, ∀Vars.Type. A let binding gives its
+// variable the scheme of its value, so that each of its occurrences may use it at another type.
+type TypeScheme struct {
+	Vars []string
+	Type entity.Type
+}
+
+// Infer computes the principal type of the closed term of ast by Hindley–Milner inference: the
+// term has this type, and every other type of the term is an instance of it. The type variables
+// of the result are named a, b, c, … in the order they first appear. A let binding, kept with
+// Config.KeepLet, is generalized, while the annotation of a parameter, if any, is taken as its type.
+// The errors are entity.Diagnostics pointing to the terms in error, like the application of a
+// variable to itself, whose type would contain itself.
+func (l *lL1PredictableParser) Infer(ast entity.Ast) (entity.Type, error) {
+	i := &inference{substitution: map[string]entity.Type{}}
+	res, ok := i.infer(ast.Term(), map[string]TypeScheme{})
+	if len(i.diagnostics) != 0 {
+		return nil, i.diagnostics
+	}
+	if !ok {
+		return nil, errMalformedTerm
+	}
+	res = replace(i.apply(res), typeNames(i.apply(res)))
+	l.logging.Debugf("inferred type %v", res)
+	return res, nil
+}
+
+// inference holds the substitution of the type variables solved so far, which grows as the
+// constraints of the term are unified (algorithm J).
+type inference struct {
+	substitution map[string]entity.Type
+	next         int
+	diagnostics  entity.Diagnostics
+}
+
+// infer returns the type of t in env, which still has to be applied the substitution, or records
+// why t has none and returns false. The sub-terms of an ill-typed application are still inferred,
+// so that the errors in both of them are reported.
+func (i *inference) infer(t entity.Term, env map[string]TypeScheme) (entity.Type, bool) {
+	switch t := t.(type) {
+	case *entity.Var:
+		scheme, ok := env[t.Name]
+		if !ok {
+			i.report(t, entity.UNBOUND_VARIABLE, "unbound variable %s", t.Name)
+			return nil, false
+		}
+		return i.instantiate(scheme), true
+	case *entity.Abs:
+		param := t.Type
+		if param == nil {
+			param = i.fresh()
+		}
+		body, ok := i.infer(t.Body, extend(env, t.Param, TypeScheme{Type: param}))
+		if !ok {
+			return nil, false
+		}
+		return entity.NewArrow(param, body), true
+	case *entity.App:
+		fun, funOk := i.infer(t.Fun, env)
+		arg, argOk := i.infer(t.Arg, env)
+		if !funOk || !argOk {
+			return nil, false
+		}
+		res := i.fresh()
+		if !i.unify(t, fun, entity.NewArrow(arg, res)) {
+			return nil, false
+		}
+		return res, true
+	case *entity.Let:
+		valueEnv := env
+		var self entity.Type
+		if t.Recursive {
+			self = i.fresh()
+			valueEnv = extend(env, t.Name, TypeScheme{Type: self})
+		}
+		value, ok := i.infer(t.Value, valueEnv)
+		if !ok || t.Recursive && !i.unify(t.Value, self, value) {
+			return nil, false
+		}
+		return i.infer(t.Body, extend(env, t.Name, i.generalize(value, env)))
+	default:
+		return nil, false
+	}
+}
+
+// fresh returns a type variable used nowhere else.
+func (i *inference) fresh() entity.Type {
+	i.next++
+	return entity.NewTypeVariable(fmt.Sprintf("t%d", i.next))
+}
+
+// instantiate replaces the generalized variables of scheme with fresh ones.
+func (i *inference) instantiate(scheme TypeScheme) entity.Type {
+	names := map[string]entity.Type{}
+	for _, v := range scheme.Vars {
+		names[v] = i.fresh()
+	}
+	return replace(scheme.Type, names)
+}
+
+// generalize quantifies t over its variables that are free nowhere in env.
+func (i *inference) generalize(t entity.Type, env map[string]TypeScheme) TypeScheme {
+	bound := map[string]bool{}
+	for _, scheme := range env {
+		quantified := map[string]bool{}
+		for _, v := range scheme.Vars {
+			quantified[v] = true
+		}
+		for _, v := range typeVariables(i.apply(scheme.Type)) {
+			bound[v] = bound[v] || !quantified[v]
+		}
+	}
+	res := TypeScheme{Type: i.apply(t)}
+	for _, v := range typeVariables(res.Type) {
+		if !bound[v] {
+			res.Vars = append(res.Vars, v)
+		}
+	}
+	return res
+}
+
+// apply replaces the solved variables of t with their solution.
+func (i *inference) apply(t entity.Type) entity.Type {
+	switch t := t.(type) {
+	case *entity.TypeVariable:
+		if res, ok := i.substitution[t.Name]; ok {
+			res = i.apply(res)
+			i.substitution[t.Name] = res
+			return res
+		}
+		return t
+	case *entity.Arrow:
+		return entity.NewArrow(i.apply(t.From), i.apply(t.To))
+	default:
+		return t
+	}
+}
+
+// unify extends the substitution so that a and b become the same type, or reports at t why they
+// cannot and returns false.
+func (i *inference) unify(t entity.Term, a entity.Type, b entity.Type) bool {
+	a, b = i.apply(a), i.apply(b)
+	if _, ok := b.(*entity.TypeVariable); ok {
+		if _, ok := a.(*entity.TypeVariable); !ok {
+			a, b = b, a
+		}
+	}
+	switch a := a.(type) {
+	case *entity.TypeVariable:
+		if entity.EqualTypes(a, b) {
+			return true
+		}
+		if occurs(a.Name, b) {
+			names := typeNames(entity.NewArrow(a, b))
+			i.report(t, entity.INFINITE_TYPE, "cannot construct the infinite type %v = %v", replace(a, names), replace(b, names))
+			return false
+		}
+		i.substitution[a.Name] = b
+		return true
+	case *entity.Arrow:
+		if b, ok := b.(*entity.Arrow); ok {
+			return i.unify(t, a.From, b.From) && i.unify(t, a.To, b.To)
+		}
+	default:
+		if entity.EqualTypes(a, b) {
+			return true
+		}
+	}
+	names := typeNames(entity.NewArrow(a, b))
+	i.report(t, entity.TYPE_MISMATCH, "cannot unify %v with %v", replace(a, names), replace(b, names))
+	return false
+}
+
+func (i *inference) report(t entity.Term, code entity.Code, format string, args ...interface{}) {
+	i.diagnostics = append(i.diagnostics, &entity.Diagnostic{
+		Severity: entity.ERROR,
+		Code:     code,
+		Span:     entity.SpanOf(t),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func extend(env map[string]TypeScheme, name string, scheme TypeScheme) map[string]TypeScheme {
+	res := make(map[string]TypeScheme, len(env)+1)
+	for k, v := range env {
+		res[k] = v
+	}
+	res[name] = scheme
+	return res
+}
+
+// occurs tells whether the variable name appears in t.
+func occurs(name string, t entity.Type) bool {
+	for _, v := range typeVariables(t) {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// typeVariables returns the names of the variables of t in the order they first appear.
+func typeVariables(t entity.Type) []string {
+	var res []string
+	seen := map[string]bool{}
+	var walk func(entity.Type)
+	walk = func(t entity.Type) {
+		switch t := t.(type) {
+		case *entity.TypeVariable:
+			if !seen[t.Name] {
+				seen[t.Name] = true
+				res = append(res, t.Name)
+			}
+		case *entity.Arrow:
+			walk(t.From)
+			walk(t.To)
+		}
+	}
+	walk(t)
+	return res
+}
+
+// typeNames names the variables of t a, b, c, …, then a1, b1, …, skipping the names of its base types.
+func typeNames(t entity.Type) map[string]entity.Type {
+	taken := map[string]bool{}
+	var walk func(entity.Type)
+	walk = func(t entity.Type) {
+		switch t := t.(type) {
+		case *entity.BaseType:
+			taken[t.Name] = true
+		case *entity.Arrow:
+			walk(t.From)
+			walk(t.To)
+		}
+	}
+	walk(t)
+
+	res := map[string]entity.Type{}
+	n := 0
+	for _, v := range typeVariables(t) {
+		name := ""
+		for name == "" || taken[name] {
+			name = string(rune('a' + n%26))
+			if n >= 26 {
+				name += fmt.Sprint(n / 26)
+			}
+			n++
+		}
+		res[v] = entity.NewTypeVariable(name)
+	}
+	return res
+}
+
+// replace substitutes types for the variables of t, leaving the others alone.
+func replace(t entity.Type, types map[string]entity.Type) entity.Type {
+	switch t := t.(type) {
+	case *entity.TypeVariable:
+		if res, ok := types[t.Name]; ok {
+			return res
+		}
+		return t
+	case *entity.Arrow:
+		return entity.NewArrow(replace(t.From, types), replace(t.To, types))
+	default:
+		return t
+	}
+}
@@ -0,0 +1,160 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Infer(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Infer principal types",
+			scenario: happyFlowInferPrincipalTypes,
+		},
+		{
+			name:     "Happy flow. Generalize let bindings",
+			scenario: happyFlowInferLetPolymorphism,
+		},
+		{
+			name:     "Happy flow. Return structured type",
+			scenario: happyFlowInferStructuredType,
+		},
+		{
+			name:     "Negative flow. Report occurs check failure",
+			scenario: negativeFlowInferOccursCheck,
+		},
+		{
+			name:     "Negative flow. Report mismatch and unbound variables",
+			scenario: negativeFlowInferErrors,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowInferPrincipalTypes(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+	var tests = []struct {
+		input string
+		res   string
+	}{
+		{input: "λf.λx.f_(f_x)", res: "(a → a) → a → a"},
+		{input: "λx.x", res: "a → a"},
+		{input: "λx.λy.x", res: "a → b → a"},
+		{input: "λf.λg.λx.f_(g_x)", res: "(a → b) → (c → a) → c → b"},
+		{input: "λx y z.x_z_(y_z)", res: "(a → b → c) → (a → b) → a → c"},
+		{input: "λx:o.λy.y", res: "o → a → a"},
+		{input: "(λx.x)_(λy.y)", res: "a → a"},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		res, err := parser.Infer(ast)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res.String(), test.res, test.input)
+	}
+}
+
+func happyFlowInferLetPolymorphism(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true, KeepLet: true})
+	var tests = []struct {
+		input string
+		res   string
+	}{
+		{input: "let id = λx.x in id id", res: "a → a"},
+		{input: "let k x y = x in k (k k) k", res: "a → b → c → b"},
+		{input: "λz.let f = λx.z in f f", res: "a → a"},
+		{input: "letrec f x = f x in f", res: "a → b"},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		res, err := parser.Infer(ast)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res.String(), test.res, test.input)
+	}
+}
+
+func happyFlowInferStructuredType(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λf.λx.f_x")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Infer(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	arrow, ok := res.(*entity.Arrow)
+	assert.Assert(t, ok)
+	assert.Assert(t, entity.EqualTypes(arrow.From, entity.NewArrow(entity.NewTypeVariable("a"), entity.NewTypeVariable("b"))))
+	assert.Assert(t, entity.EqualTypes(arrow.To, arrow.From))
+}
+
+func negativeFlowInferOccursCheck(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.x_x")
+	ast, _ := parser.Parse(tk)
+	_, err := parser.Infer(ast)
+
+	// assert
+	diagnostics, ok := err.(entity.Diagnostics)
+	assert.Assert(t, ok)
+	assert.Equal(t, diagnostics[0].Code, entity.INFINITE_TYPE)
+	assert.Equal(t, diagnostics[0].Span.String(), "1:4-1:7")
+	assert.Equal(t, err.Error(), "1:4: error[T006]: cannot construct the infinite type a = a → b")
+}
+
+func negativeFlowInferErrors(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("(λf:o→o.f) (λx:p.x) y")
+	ast, _ := parser.Parse(tk)
+	_, err := parser.Infer(ast)
+
+	// assert
+	assert.Equal(t, err.Error(), ""+
+		"1:1: error[T003]: cannot unify o with p\n"+
+		"1:21: error[T001]: unbound variable y")
+}
@@ -0,0 +1,148 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Juxtaposition(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Parse left-associative application",
+			scenario: happyFlowParseJuxtapositionLeftAssociative,
+		},
+		{
+			name:     "Happy flow. Parse abstraction body extending to the right",
+			scenario: happyFlowParseJuxtapositionAbstractionBody,
+		},
+		{
+			name:     "Happy flow. Reduce juxtaposed application",
+			scenario: happyFlowReduceJuxtaposition,
+		},
+		{
+			name:     "Happy flow. Round-trip unparsed terms",
+			scenario: happyFlowRoundTripJuxtaposition,
+		},
+		{
+			name:     "Negative flow. Reject underscore application",
+			scenario: negativeFlowRejectUnderscoreInJuxtaposition,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowParseJuxtapositionLeftAssociative(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	explicit := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("f x (g y) z")
+	ast, err := parser.Parse(tk)
+	res, _ := explicit.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(((f_x)_(g_y))_z)")
+	assert.Equal(t, entity.SpanOf(ast.Term()).String(), "1:1-1:12")
+	assert.Equal(t, entity.SpanOf(ast.Term().(*entity.App).Fun).String(), "1:1-1:10")
+}
+
+func happyFlowParseJuxtapositionAbstractionBody(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	explicit := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("f λx.x y λz.z")
+	ast, err := parser.Parse(tk)
+	res, _ := explicit.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(f_(λx.((x_y)_(λz.z))))")
+}
+
+func happyFlowReduceJuxtaposition(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.λy.x) a b")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.Reduce(ast, NewNormalOrderStrategy())
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "a")
+}
+
+func happyFlowRoundTripJuxtaposition(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	var tests = []struct {
+		input    string
+		unparsed string
+	}{
+		{input: "f x y", unparsed: "f x y"},
+		{input: "f (x y)", unparsed: "f (x y)"},
+		{input: "(λx.x) y", unparsed: "(λx.x) y"},
+		{input: "f (λx.x) y", unparsed: "f (λx.x) y"},
+		{input: "f (λx.x y)", unparsed: "f λx.x y"},
+		{input: "((f x)) (λy.(y)) (z)", unparsed: "f x (λy.y) z"},
+		{input: "λf.λx.f (f x)", unparsed: "λf.λx.f (f x)"},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		res, err := parser.Unparse(ast)
+		tk, _ = analyzer.Tokenize(res)
+		again, err := parser.Parse(tk)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res, test.unparsed)
+		assert.Assert(t, AlphaEquivalent(ast, again), test.input)
+	}
+}
+
+func negativeFlowRejectUnderscoreInJuxtaposition(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("f_x")
+	_, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err.Error(), "1:2: error[P001]: expected variable, λ, (, let, letrec or end of input, found _")
+}
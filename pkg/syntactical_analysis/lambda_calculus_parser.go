@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math-parser/pkg/entity"
 	"math-parser/pkg/utils/logging"
+	"sort"
 )
 
 const (
@@ -15,229 +16,423 @@ const (
 	LAMBDA        = "λ"
 	EPSILON       = "ε"
 	TERMS         = "Λs"
+	PARAMETERS    = "vs"
+	DEFINITION    = "D"
+	ANNOTATION    = "a"
+	TYPE          = "τ"
+	TYPES         = "τs"
+	COLON         = ":"
+	ARROW         = "→"
+	LET           = "let"
+	LETREC        = "letrec"
+	IN            = "in"
+	EQUALS        = "="
 	LEFT_BRACKET  = "("
 	RIGHT_BRACKET = ")"
+	INVALID       = "error"
 )
 
+// Config tunes the parser. In lenient mode the parser reads the longest prefix of the input that is
+// a term and ignores the rest, like x in x), while by default such trailing input is a syntax error.
+//
+// With Juxtaposition the parser reads the conventional syntax, where f x y stands for (f x) y
+// and the body of an abstraction extends as far right as possible, instead of f_x_y.
+//
+// Application associates to the left, x_y_z being (x_y)_z, unless RightAssociative reads it
+// as x_(y_z). Juxtaposition is always left associative.
+//
+// A let binding is expanded to a redex, let x = M in N becoming (λx.N)_M, unless KeepLet keeps
+// it as an entity.Let for the printers and type inference.
+type Config struct {
+	Lenient          bool
+	Juxtaposition    bool
+	RightAssociative bool
+	KeepLet          bool
+}
+
+// DefaultConfig parses in strict mode: the whole input must be a single term.
+func DefaultConfig() Config {
+	return Config{}
+}
+
 func NewLL1PredictableParser(ctx context.Context) LL1PredictableParser {
+	return NewConfiguredLL1PredictableParser(ctx, DefaultConfig())
+}
+
+func NewConfiguredLL1PredictableParser(ctx context.Context, config Config) LL1PredictableParser {
 	return &lL1PredictableParser{
+		ctx:     ctx,
 		logging: ctx.Value("logger").(logging.Logger),
+		config:  config,
 	}
 }
 
 type LL1PredictableParser interface {
 	Parse([]entity.Token) (entity.Ast, error)
+	ParseTree([]entity.Token) (entity.ParseTree, error)
+	ParseDefinitions([]entity.Token) (Definitions, error)
 	Unparse(entity.Ast) (string, error)
+	Decode(ast entity.Ast, shape Shape) (interface{}, error)
+	TypeCheck(entity.Ast) (*Derivation, error)
+	Infer(entity.Ast) (entity.Type, error)
 	BetaReduce(entity.Ast) (entity.Ast, error)
+	DeltaReduce(entity.Ast) (entity.Ast, error)
+	EtaReduce(entity.Ast) (entity.Ast, error)
+	EtaExpand(entity.Ast) (entity.Ast, error)
+	BetaEtaEquivalent(a entity.Ast, b entity.Ast) (bool, error)
+	Reduce(ast entity.Ast, strategy ReductionStrategy) (entity.Ast, error)
+	Trace(ast entity.Ast, strategy ReductionStrategy) ([]TraceStep, error)
+	Normalize(ctx context.Context, ast entity.Ast, opts NormalizationOptions) (entity.Ast, error)
 	AlphaReduce(ast entity.Ast, sub map[string]string) (entity.Ast, error)
 }
 
 type lL1PredictableParser struct {
+	ctx     context.Context
 	logging logging.Logger
+	config  Config
 	buffer  entity.TokenBuffer
+	// pending holds the terminals that could have continued the symbols derived to ε since the last token.
+	pending []entity.Tag
+	// brackets and lets count the brackets and the let bindings opened and not closed yet, which
+	// alone may be followed by ) and in.
+	brackets    int
+	lets        int
+	diagnostics entity.Diagnostics
 }
 
+// bufferInit appends to t the end of input token, placed right after the last token.
 func (l *lL1PredictableParser) bufferInit(t []entity.Token) {
-	l.buffer = entity.NewTokenBuffer(append(t, entity.Token{Tag: entity.EPSILON}))
+	end := entity.Location{Line: 1, Column: 1}
+	if len(t) != 0 {
+		end = t[len(t)-1].Span.End
+	}
+	l.buffer = entity.NewTokenBuffer(append(t, entity.Token{Tag: entity.EPSILON, Span: entity.Span{Start: end, End: end}}))
+	l.pending = nil
+	l.brackets, l.lets = 0, 0
+	l.diagnostics = nil
 }
 
 func (l *lL1PredictableParser) AlphaReduce(ast entity.Ast, sub map[string]string) (entity.Ast, error) {
+	term := ast.Term()
 	for old, new := range sub {
 		if _, ok := sub[new]; ok {
 			return nil, errors.New("substitutions vars can be reduced")
 		}
-		err := l.alphaReduce(ast.Root(), entity.NewVariableToken(old), entity.NewVariableToken(new), nil)
-		if err != nil {
+		var err error
+		if term, err = l.alphaReduce(term, old, new); err != nil {
 			return nil, err
 		}
 	}
+	ast = entity.NewAst(term)
 
 	l.logging.Debugf("ast after alpha-reduction:\n%s", ast.Visualize())
 	return ast, nil
 }
 
-func (l *lL1PredictableParser) alphaReduce(node entity.Node, old *entity.Token, new *entity.Token, dependentVar entity.Node) error {
-	if l.getDependentVar(node) != nil {
-		dependentVar = l.getDependentVar(node)
-	}
-	if dependentVar != nil && *new == *dependentVar.Token() {
-		return errors.New("wrong alpha-reduction")
-	}
-	for i, child := range node.Child() {
-		if *child.Token() == *old {
-			node.Replace(i, entity.NewNode(fmt.Sprintf("%s", new.Value), *new))
-		} else if !entity.IsTerminal(child.Token().Tag) {
-			if err := l.alphaReduce(child, old, new, nil); err != nil {
-				return err
-			}
+// alphaReduce renames every occurrence of old in t, binders included. It fails when an
+// abstraction of t already binds new.
+func (l *lL1PredictableParser) alphaReduce(t entity.Term, old string, new string) (entity.Term, error) {
+	switch t := t.(type) {
+	case *entity.Var:
+		if t.Name == old {
+			return t.Rename(new), nil
+		}
+		return t, nil
+	case *entity.Abs:
+		if t.Param == new {
+			return nil, errors.New("wrong alpha-reduction")
 		}
+		body, err := l.alphaReduce(t.Body, old, new)
+		if err != nil {
+			return nil, err
+		}
+		param := t.Param
+		if param == old {
+			param = new
+		}
+		return t.Rebuild(param, body), nil
+	case *entity.App:
+		fun, err := l.alphaReduce(t.Fun, old, new)
+		if err != nil {
+			return nil, err
+		}
+		arg, err := l.alphaReduce(t.Arg, old, new)
+		if err != nil {
+			return nil, err
+		}
+		return t.Rebuild(fun, arg), nil
+	default:
+		return nil, errMalformedTerm
 	}
-	return nil
 }
 
-func (l *lL1PredictableParser) getDependentVar(node entity.Node) entity.Node {
-	if len(node.Child()) > 0 && node.Child()[0].Token().Tag == entity.LAMBDA {
-		return node.Child()[1]
-	}
-	return nil
-}
 func (l *lL1PredictableParser) BetaReduce(ast entity.Ast) (entity.Ast, error) {
-	err := l.betaReduce(ast.Root())
-	if err != nil {
-		return nil, err
-	}
+	ast = entity.NewAst(l.betaReduce(ast.Term()))
 
 	l.logging.Debugf("ast after beta-reduction:\n%s", ast.Visualize())
 	return ast, nil
 }
 
-func (l *lL1PredictableParser) betaReduce(n entity.Node) error {
-	for _, child := range n.Child() {
-		if err := l.betaReduce(child); err != nil {
-			return err
+// betaReduce contracts the redexes of t in a single bottom-up sweep.
+func (l *lL1PredictableParser) betaReduce(t entity.Term) entity.Term {
+	switch t := t.(type) {
+	case *entity.Abs:
+		return t.Rebuild(t.Param, l.betaReduce(t.Body))
+	case *entity.App:
+		fun, arg := l.betaReduce(t.Fun), l.betaReduce(t.Arg)
+		if abs, ok := fun.(*entity.Abs); ok {
+			return substitute(abs.Body, abs.Param, arg)
 		}
+		return t.Rebuild(fun, arg)
+	default:
+		return t
 	}
+}
 
-	if len(n.Child()) == 3 {
-		lo := n.Child()[0]
-		ro := n.Child()[2]
+// Reduce normalizes ast with the given strategy, bounded by the default limits and the parser context.
+func (l *lL1PredictableParser) Reduce(ast entity.Ast, strategy ReductionStrategy) (entity.Ast, error) {
+	return l.Normalize(l.ctx, ast, NormalizationOptions{Strategy: strategy})
+}
 
-		if len(lo.Child()) == 0 || lo.Child()[0].Token().Tag != entity.LAMBDA {
-			return nil
-		}
+func (l *lL1PredictableParser) Parse(t []entity.Token) (entity.Ast, error) {
+	tree, err := l.ParseTree(t)
+	if tree == nil {
+		return nil, err
+	}
 
-		localVar := lo.Child()[1]
-		lo.Delete(2)
-		lo.Delete(1)
-		lo.Delete(0)
-		l.apply(lo, ro, localVar)
+	lower := Lower
+	if l.config.RightAssociative {
+		lower = LowerRightAssociative
+	}
+	ast, lowerErr := lower(tree)
+	if lowerErr != nil {
+		return nil, lowerErr
+	}
+	if !l.config.KeepLet {
+		ast = Desugar(ast)
+	}
+	l.logging.Debugf("lowered ast: \n%v", ast.Visualize())
 
-		n.Delete(2)
-		n.Delete(1)
+	return ast, err
+}
+
+// grammar returns the grammar selected by the configuration.
+func (l *lL1PredictableParser) grammar() grammar {
+	if l.config.Juxtaposition {
+		return juxtapositionGrammar
 	}
-	return nil
+	return underscoreGrammar
+}
 
+// ParseTree recovers from syntax errors: it returns the entity.Diagnostics of all of them
+// together with a partial tree, in which INVALID nodes stand for the parts in error.
+func (l *lL1PredictableParser) ParseTree(t []entity.Token) (entity.ParseTree, error) {
+	return l.parseTree(t, entity.TERM)
 }
 
-func (l *lL1PredictableParser) apply(lop entity.Node, rop entity.Node, localVar entity.Node) {
-	for i, child := range lop.Child() {
-		if *child.Token() == *localVar.Token() {
-			lop.Replace(i, rop)
-		} else if child.Token().Tag == entity.LAMBDA {
-			return
+// parseTree derives the whole of t from nonTerminalTag.
+func (l *lL1PredictableParser) parseTree(t []entity.Token, nonTerminalTag entity.Tag) (entity.ParseTree, error) {
+	l.bufferInit(t)
+
+	root, err := l.parse(nonTerminalTag)
+	if err != nil {
+		return nil, err
+	}
+	if lookahead := l.buffer.Lookahead(); lookahead.Tag != entity.EPSILON {
+		if l.config.Lenient {
+			l.logging.Debugf("ignored trailing input from %v", lookahead.Span.Start)
 		} else {
-			l.apply(child, rop, localVar)
+			l.report(append(l.pending, entity.EPSILON))
 		}
 	}
-}
+	tree := entity.NewParseTree(root)
+	l.logging.Debugf("computed parse tree: \n%v", tree.Visualize())
 
-func (l *lL1PredictableParser) simplify(node entity.Node) {
-	for i := len(node.Child()) - 1; i >= 0; i-- {
-		l.simplify(node.Child()[i])
+	if len(l.diagnostics) != 0 {
+		return tree, l.diagnostics
+	}
+	return tree, nil
+}
 
-		if len(node.Child()[i].Child()) == 0 && !entity.IsTerminal(node.Child()[i].Token().Tag) {
-			node.Delete(i)
-		} else {
-			switch node.Child()[i].Token().Tag {
-			case entity.EPSILON, entity.LEFT_BRACKET, entity.RIGHT_BRACKET:
-				{
-					node.Delete(i)
+// parse derives nonTerminalTag from the buffer. On a syntax error it records a diagnostic and
+// recovers in panic mode: it skips tokens up to one that can start or follow what was expected,
+// and puts an INVALID node in place of what is missing.
+func (l *lL1PredictableParser) parse(nonTerminalTag entity.Tag) (entity.Node, error) {
+	g := l.grammar()
+	rule, ok := g.rules[nonTerminalTag]
+	if !ok {
+		return nil, fmt.Errorf("%v is not a non-terminal", nonTerminalTag)
+	}
+	res := l.NewNodeFromNonTerminal(nonTerminalTag)
+	prod, ok := rule[l.buffer.Lookahead().Tag]
+	_, nullable := rule[entity.EPSILON]
+	// The parameters vs, their annotations a and the arrows τs derive ε on any unexpected lookahead,
+	// leaving the error to the symbol after them, which tells better what was expected: . in an
+	// abstraction, = in a binding.
+	derivesEpsilon := nullable && (containsTag([]entity.Tag{entity.PARAMETERS, entity.ANNOTATION, entity.TYPES}, nonTerminalTag) ||
+		containsTag(g.follow[nonTerminalTag], l.buffer.Lookahead().Tag))
+	if !ok && !derivesEpsilon && !(nonTerminalTag == entity.TERMS && l.config.Lenient) {
+		l.report(g.lookaheads(nonTerminalTag))
+		skipped := l.synchronize(append(g.first(nonTerminalTag), g.follow[nonTerminalTag]...))
+		if prod, ok = rule[l.buffer.Lookahead().Tag]; !ok && (nonTerminalTag == entity.TERM || nonTerminalTag == entity.TYPE) {
+			res.AddChildToEnd(l.NewNodeFromTerminal(*entity.NewErrorToken(skipped)))
+			return res, nil
+		}
+	}
+	if nullable && len(prod) < 2 {
+		for _, t := range g.first(nonTerminalTag) {
+			if t != entity.EPSILON {
+				l.pending = append(l.pending, t)
+			}
+		}
+	}
+	for i, t := range prod {
+		var child entity.Node
+		if t == entity.EPSILON {
+			child = l.NewNodeFromTerminal(*entity.NewEpsilonToken())
+		} else if entity.IsTerminal(t) {
+			if l.buffer.Lookahead().Tag != t {
+				l.report(append(l.pending, t))
+				sync := append([]entity.Tag{t}, g.follow[nonTerminalTag]...)
+				if i+1 < len(prod) {
+					sync = append(sync, g.lookaheads(prod[i+1])...)
 				}
-			case entity.TERMS:
-				{
-					node.Replace(i, node.Child()[i].Child()...)
+				if skipped := l.synchronize(sync); l.buffer.Lookahead().Tag != t {
+					res.AddChildToEnd(l.NewNodeFromTerminal(*entity.NewErrorToken(skipped)))
+					continue
 				}
 			}
-
+			l.buffer.NextToken()
+			l.pending = nil
+			l.open(t)
+			child = l.NewNodeFromTerminal(*l.buffer.Current())
+		} else {
+			var err error
+			if child, err = l.parse(t); err != nil {
+				return nil, err
+			}
 		}
+		res.AddChildToEnd(child)
 	}
-
+	return res, nil
 }
 
-func (l *lL1PredictableParser) Parse(t []entity.Token) (entity.Ast, error) {
-	l.bufferInit(t)
-
-	root, err := l.parse(entity.TERM)
-	if err != nil {
-		return nil, err
+// synchronize skips tokens until the lookahead is one of sync or the end of input. It returns
+// the span of the skipped tokens, which is empty and right before the lookahead if none was.
+func (l *lL1PredictableParser) synchronize(sync []entity.Tag) entity.Span {
+	start := l.buffer.Lookahead().Span.Start
+	span := entity.Span{Start: start, End: start}
+	for tag := l.buffer.Lookahead().Tag; tag != entity.EPSILON && !containsTag(sync, tag); tag = l.buffer.Lookahead().Tag {
+		l.buffer.NextToken()
+		span = span.Join(l.buffer.Current().Span)
 	}
-	ast := entity.NewAst(root)
-	l.logging.Debugf("computed ast: \n%v", ast.Visualize())
+	return span
+}
 
-	l.simplify(ast.Root())
-	if err != nil {
-		return nil, err
+// open keeps count of the brackets and let bindings left open once the terminal t is read.
+func (l *lL1PredictableParser) open(t entity.Tag) {
+	switch t {
+	case entity.LEFT_BRACKET:
+		l.brackets++
+	case entity.RIGHT_BRACKET:
+		l.brackets--
+	case entity.LET, entity.LETREC:
+		l.lets++
+	case entity.IN:
+		l.lets--
 	}
-	l.logging.Debugf("simplified ast: \n%v", ast.Visualize())
+}
 
-	return ast, nil
+// report records a syntax error at the lookahead, unless one was already recorded there. The
+// expected ) and in are left out unless a bracket or a let binding is open to be closed by them,
+// and the end of input while one is.
+func (l *lL1PredictableParser) report(expected []entity.Tag) {
+	var tags []entity.Tag
+	for _, t := range expected {
+		if t == entity.RIGHT_BRACKET && l.brackets == 0 || t == entity.IN && l.lets == 0 ||
+			t == entity.EPSILON && (l.brackets != 0 || l.lets != 0) {
+			continue
+		}
+		tags = append(tags, t)
+	}
+	diagnostic := l.syntaxError(tags)
+	if n := len(l.diagnostics); n != 0 && l.diagnostics[n-1].Span.Start == diagnostic.Span.Start {
+		return
+	}
+	l.diagnostics = append(l.diagnostics, diagnostic)
 }
 
-func (l *lL1PredictableParser) parse(nonTerminalTag entity.Tag) (entity.Node, error) {
-	rules := map[entity.Tag]map[entity.Tag][]entity.Tag{
-		entity.TERM: {
-			entity.VARIABLE:     {entity.VARIABLE, entity.TERMS},
-			entity.LAMBDA:       {entity.LAMBDA, entity.VARIABLE, entity.ABSTRACTION, entity.TERM, entity.TERMS},
-			entity.LEFT_BRACKET: {entity.LEFT_BRACKET, entity.TERM, entity.RIGHT_BRACKET, entity.TERMS},
-		},
-		entity.TERMS: {
-			entity.APPLICATION: {entity.APPLICATION, entity.TERM},
-			entity.EPSILON:     {entity.EPSILON},
-		},
+// syntaxError reports the lookahead, which is none of the expected tokens.
+func (l *lL1PredictableParser) syntaxError(expected []entity.Tag) *entity.Diagnostic {
+	var tags []entity.Tag
+	for _, t := range expected {
+		if !containsTag(tags, t) {
+			tags = append(tags, t)
+		}
 	}
-	if rule, ok := rules[nonTerminalTag]; !ok {
-		return nil, errors.New("can't define rule")
-	} else {
-		res := l.NewNodeFromNonTerminal(nonTerminalTag)
-		if prod, ok := rule[l.buffer.Lookahead().Tag]; ok {
-			for _, t := range prod {
-				var child entity.Node
-				if t == entity.EPSILON {
-					child = l.NewNodeFromTerminal(*entity.NewEpsilonToken())
-				} else if entity.IsTerminal(t) {
-					l.buffer.NextToken()
-					child = l.NewNodeFromTerminal(*l.buffer.Current())
-					if child.Token().Tag != t {
-						return nil, errors.New(fmt.Sprintf("expected %d instead of %v", t, child))
-					}
-				} else {
-					var err error
-					if child, err = l.parse(t); err != nil {
-						return nil, err
-					}
-				}
-				res.AddChildToEnd(child)
-			}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+	return entity.NewSyntaxDiagnostic(tags, *l.buffer.Lookahead())
+}
+
+func containsTag(tags []entity.Tag, tag entity.Tag) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
 		}
-		return res, nil
 	}
+	return false
 }
 
 func (l *lL1PredictableParser) Unparse(ast entity.Ast) (string, error) {
-	res, err := l.unparse(ast.Root())
+	unparse := l.unparse
+	if l.config.Juxtaposition {
+		unparse = func(t entity.Term) (string, error) {
+			return printer{opts: PrintOptions{Notation: Notation{Juxtaposition: true}}}.write(t, true, 0)
+		}
+	}
+	res, err := unparse(ast.Term())
 	l.logging.Debugf(`unparsed to "%s"`, res)
 	return res, err
 }
 
-func (l *lL1PredictableParser) unparse(node entity.Node) (string, error) {
-	if entity.IsTerminal(node.Token().Tag) {
-		return node.Label(), nil
-	}
-
-	var res string
-	for _, child := range node.Child() {
-		parseChild, err := l.unparse(child)
+// unparse prints t with every abstraction and application in brackets.
+func (l *lL1PredictableParser) unparse(t entity.Term) (string, error) {
+	switch t := t.(type) {
+	case *entity.Var:
+		return t.Name, nil
+	case *entity.Abs:
+		body, err := l.unparse(t.Body)
 		if err != nil {
-			return res, err
+			return "", err
 		}
-		res += parseChild
-	}
-
-	if node.Token().Tag == entity.TERM && len(node.Child()) > 2 {
-		res = "(" + res + ")"
+		param := t.Param
+		if t.Type != nil {
+			param += COLON + t.Type.String()
+		}
+		return "(" + LAMBDA + param + ABSTRACTION + body + ")", nil
+	case *entity.App:
+		fun, err := l.unparse(t.Fun)
+		if err != nil {
+			return "", err
+		}
+		arg, err := l.unparse(t.Arg)
+		if err != nil {
+			return "", err
+		}
+		return "(" + fun + APPLICATION + arg + ")", nil
+	case *entity.Let:
+		value, err := l.unparse(t.Value)
+		if err != nil {
+			return "", err
+		}
+		body, err := l.unparse(t.Body)
+		if err != nil {
+			return "", err
+		}
+		return "(" + letKeyword(t) + " " + t.Name + " " + EQUALS + " " + value + " " + IN + " " + body + ")", nil
+	default:
+		return "", errMalformedTerm
 	}
-
-	return res, nil
 }
 
 func (l lL1PredictableParser) NewNodeFromNonTerminal(t entity.Tag) entity.Node {
@@ -256,6 +451,41 @@ func (l lL1PredictableParser) NewNodeFromNonTerminal(t entity.Tag) entity.Node {
 				Value: TERMS,
 			})
 		}
+	case entity.PARAMETERS:
+		{
+			return entity.NewNode(PARAMETERS, entity.Token{
+				Tag:   t,
+				Value: PARAMETERS,
+			})
+		}
+	case entity.DEFINITION:
+		{
+			return entity.NewNode(DEFINITION, entity.Token{
+				Tag:   t,
+				Value: DEFINITION,
+			})
+		}
+	case entity.ANNOTATION:
+		{
+			return entity.NewNode(ANNOTATION, entity.Token{
+				Tag:   t,
+				Value: ANNOTATION,
+			})
+		}
+	case entity.TYPE:
+		{
+			return entity.NewNode(TYPE, entity.Token{
+				Tag:   t,
+				Value: TYPE,
+			})
+		}
+	case entity.TYPES:
+		{
+			return entity.NewNode(TYPES, entity.Token{
+				Tag:   t,
+				Value: TYPES,
+			})
+		}
 	default:
 		{
 			return nil
@@ -289,10 +519,38 @@ func (l lL1PredictableParser) NewNodeFromTerminal(t entity.Token) entity.Node {
 		{
 			return entity.NewNode(RIGHT_BRACKET, t)
 		}
+	case entity.LET:
+		{
+			return entity.NewNode(LET, t)
+		}
+	case entity.LETREC:
+		{
+			return entity.NewNode(LETREC, t)
+		}
+	case entity.IN:
+		{
+			return entity.NewNode(IN, t)
+		}
+	case entity.EQUALS:
+		{
+			return entity.NewNode(EQUALS, t)
+		}
+	case entity.COLON:
+		{
+			return entity.NewNode(COLON, t)
+		}
+	case entity.ARROW:
+		{
+			return entity.NewNode(ARROW, t)
+		}
 	case entity.EPSILON:
 		{
 			return entity.NewNode(EPSILON, t)
 		}
+	case entity.INVALID:
+		{
+			return entity.NewNode(INVALID, t)
+		}
 	default:
 		{
 			l.logging.Debugf("unknown token tag %s", t)
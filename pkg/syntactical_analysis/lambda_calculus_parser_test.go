@@ -37,6 +37,10 @@ func TestLexicalAnalyzer_Parse(t *testing.T) {
 			name:     "Happy flow. Parse expression with brackets1",
 			scenario: happyFlowParseExpressionWithBrackets1,
 		},
+		{
+			name:     "Happy flow. Parse expression with multi-character identifiers",
+			scenario: happyFlowParseExpressionWithMultiCharacterIdentifiers,
+		},
 	}
 
 	t.Parallel()
@@ -135,6 +139,23 @@ func happyFlowParseExpressionWithBrackets1(t *testing.T) {
 	assert.Equal(t, err, nil)
 }
 
+func happyFlowParseExpressionWithMultiCharacterIdentifiers(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λsucc.λn'.succ_(succ_n')")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λsucc.(λn'.(succ_(succ_n'))))")
+}
+
 func TestLexicalAnalyzer_Unparse(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -236,7 +257,7 @@ func happyFlowUnparseExpressionWithApplicationAndAbstraction(t *testing.T) {
 	res, err := parser.Unparse(ast)
 	// assert
 	assert.Equal(t, err, nil)
-	assert.Equal(t, res, "(x_(λy.(x_(y_z))))")
+	assert.Equal(t, res, "(x_(λy.((x_y)_z)))")
 }
 
 func happyFlowUnparseExpressionWithBrackets(t *testing.T) {
@@ -253,7 +274,7 @@ func happyFlowUnparseExpressionWithBrackets(t *testing.T) {
 	res, err := parser.Unparse(ast)
 	// assert
 	assert.Equal(t, err, nil)
-	assert.Equal(t, res, "(x_((λy.x)_(y_z)))")
+	assert.Equal(t, res, "(((x_(λy.x))_y)_z)")
 }
 
 func happyFlowUnparseExpressionWithBrackets1(t *testing.T) {
@@ -270,7 +291,7 @@ func happyFlowUnparseExpressionWithBrackets1(t *testing.T) {
 	res, err := parser.Unparse(ast)
 	// assert
 	assert.Equal(t, err, nil)
-	assert.Equal(t, res, "(x_((λy.x)_(y_(z_z))))")
+	assert.Equal(t, res, "(((x_(λy.x))_y)_(z_z))")
 }
 
 func TestLexicalAnalyzer_BetaReduction(t *testing.T) {
@@ -326,6 +347,18 @@ func TestLexicalAnalyzer_BetaReduction(t *testing.T) {
 			name:     "Happy flow. Beta reduction12",
 			scenario: happyFlowBetaReduction12,
 		},
+		{
+			name:     "Happy flow. Beta reduction avoids variable capture",
+			scenario: happyFlowBetaReductionAvoidsCapture,
+		},
+		{
+			name:     "Happy flow. Beta reduction respects shadowing",
+			scenario: happyFlowBetaReductionRespectsShadowing,
+		},
+		{
+			name:     "Happy flow. Beta reduction renames nested binders",
+			scenario: happyFlowBetaReductionRenamesNestedBinders,
+		},
 	}
 
 	t.Parallel()
@@ -404,7 +437,7 @@ func happyFlowBetaReduction4(t *testing.T) {
 	res, err := parser.Unparse(ast)
 	// assert
 	assert.Equal(t, err, nil)
-	assert.Equal(t, res, "(t_(z_t))")
+	assert.Equal(t, res, "((t_z)_t)")
 }
 
 func happyFlowBetaReduction5(t *testing.T) {
@@ -422,7 +455,7 @@ func happyFlowBetaReduction5(t *testing.T) {
 	res, err := parser.Unparse(ast)
 	// assert
 	assert.Equal(t, err, nil)
-	assert.Equal(t, res, "z")
+	assert.Equal(t, res, "(z_x)")
 }
 
 func happyFlowBetaReduction6(t *testing.T) {
@@ -548,7 +581,61 @@ func happyFlowBetaReduction12(t *testing.T) {
 	res, err := parser.Unparse(ast)
 	// assert
 	assert.Equal(t, err, nil)
-	assert.Equal(t, res, "((z_z)_((z_z)_r))")
+	assert.Equal(t, res, "(((z_z)_(z_z))_r)")
+}
+
+func happyFlowBetaReductionAvoidsCapture(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	expression := "(λx.λy.x)_y"
+	tk, _ := analyzer.Tokenize(expression)
+	ast, err := parser.Parse(tk)
+	ast, err = parser.BetaReduce(ast)
+	res, err := parser.Unparse(ast)
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λy'.y)")
+}
+
+func happyFlowBetaReductionRespectsShadowing(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	expression := "(λx.x_λx.x)_y"
+	tk, _ := analyzer.Tokenize(expression)
+	ast, err := parser.Parse(tk)
+	ast, err = parser.BetaReduce(ast)
+	res, err := parser.Unparse(ast)
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(y_(λx.x))")
+}
+
+func happyFlowBetaReductionRenamesNestedBinders(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	expression := "(λx.λy.λz.x_y_z)_(y_z)"
+	tk, _ := analyzer.Tokenize(expression)
+	ast, err := parser.Parse(tk)
+	ast, err = parser.BetaReduce(ast)
+	res, err := parser.Unparse(ast)
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λy'.(λz'.(((y_z)_y')_z')))")
 }
 
 func TestLexicalAnalyzer_AlphaReduction(t *testing.T) {
@@ -0,0 +1,61 @@
+package syntactical_analyzer
+
+import "math-parser/pkg/entity"
+
+// Desugar expands the let bindings of ast: let x = M in N becomes (λx.N)_M, and letrec x = M in N
+// becomes (λx.N)_(Y_(λx.M)) when x is free in M, Y being the fixed-point combinator.
+// The redex takes the span of the binding.
+func Desugar(ast entity.Ast) entity.Ast {
+	return entity.NewAst(desugar(ast.Term()))
+}
+
+func desugar(t entity.Term) entity.Term {
+	switch t := t.(type) {
+	case *entity.Abs:
+		if body := desugar(t.Body); body != t.Body {
+			return t.Rebuild(t.Param, body)
+		}
+		return t
+	case *entity.App:
+		fun, arg := desugar(t.Fun), desugar(t.Arg)
+		if fun != t.Fun || arg != t.Arg {
+			return t.Rebuild(fun, arg)
+		}
+		return t
+	case *entity.Let:
+		value := desugar(t.Value)
+		if t.Recursive {
+			value = recursive(t.Name, value)
+		}
+		return &entity.App{
+			Fun:  &entity.Abs{Param: t.Name, Body: desugar(t.Body), Span: t.Span},
+			Arg:  value,
+			Span: t.Span,
+		}
+	default:
+		return t
+	}
+}
+
+// recursive ties a value referring to name to itself with the fixed-point combinator.
+func recursive(name string, value entity.Term) entity.Term {
+	if !freeVariables(value)[name] {
+		return value
+	}
+	return entity.NewApp(fixedPoint(), entity.NewAbs(name, value))
+}
+
+// fixedPoint returns Y = λf.(λx.f_(x_x))_(λx.f_(x_x)).
+func fixedPoint() entity.Term {
+	self := func() entity.Term {
+		return entity.NewAbs("x", entity.NewApp(entity.NewVar("f"), entity.NewApp(entity.NewVar("x"), entity.NewVar("x"))))
+	}
+	return entity.NewAbs("f", entity.NewApp(self(), self()))
+}
+
+func letKeyword(t *entity.Let) string {
+	if t.Recursive {
+		return LETREC
+	}
+	return LET
+}
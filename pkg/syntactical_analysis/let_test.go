@@ -0,0 +1,142 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Let(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Expand let binding to a redex",
+			scenario: happyFlowExpandLet,
+		},
+		{
+			name:     "Happy flow. Bind a function with parameters",
+			scenario: happyFlowLetWithParameters,
+		},
+		{
+			name:     "Happy flow. Reduce recursive binding",
+			scenario: happyFlowReduceLetrec,
+		},
+		{
+			name:     "Happy flow. Keep let binding",
+			scenario: happyFlowKeepLet,
+		},
+		{
+			name:     "Negative flow. Report missing equals sign",
+			scenario: negativeFlowReportMissingEquals,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowExpandLet(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("let id = λx.x in id_y")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+	normal, err := parser.Reduce(ast, NewNormalOrderStrategy())
+	normalRes, err := parser.Unparse(normal)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "((λid.(id_y))_(λx.x))")
+	assert.Equal(t, entity.SpanOf(ast.Term()).String(), "1:1-1:22")
+	assert.Equal(t, normalRes, "y")
+}
+
+func happyFlowLetWithParameters(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("f let k x y = x in k a b")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.Reduce(ast, NewNormalOrderStrategy())
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "f a")
+}
+
+func happyFlowReduceLetrec(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("letrec f b = b a (f λx y.x) in f λx y.y")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.Reduce(ast, NewNormalOrderStrategy())
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "a")
+}
+
+func happyFlowKeepLet(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true, KeepLet: true})
+	explicit := NewConfiguredLL1PredictableParser(ctx, Config{KeepLet: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("(letrec x = f x in x) (let y = a in y)")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+	explicitRes, err := explicit.Unparse(ast)
+	tk, _ = analyzer.Tokenize(res)
+	again, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err, nil)
+	let := ast.Term().(*entity.App).Fun.(*entity.Let)
+	assert.Equal(t, let.Name, "x")
+	assert.Equal(t, let.Recursive, true)
+	assert.Equal(t, let.Span.String(), "1:2-1:21")
+	assert.Equal(t, res, "(letrec x = f x in x) let y = a in y")
+	assert.Equal(t, explicitRes, "((letrec x = (f_x) in x)_(let y = a in y))")
+	assert.DeepEqual(t, again.Term().(*entity.App).Arg.(*entity.Let).Name, "y")
+}
+
+func negativeFlowReportMissingEquals(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("let x a in x")
+	_, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err.Error(), "1:9: error[P001]: expected variable, = or :, found in")
+}
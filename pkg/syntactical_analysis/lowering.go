@@ -0,0 +1,278 @@
+package syntactical_analyzer
+
+import (
+	"errors"
+	"fmt"
+	"math-parser/pkg/entity"
+)
+
+var errMalformedTerm = errors.New("malformed term")
+
+// Lower builds the Var, Abs, App and Let nodes of a parse tree produced by the underscore or the
+// juxtaposition grammar, where application associates to the left: x_y_z is (x_y)_z.
+//
+// A term in error, or an abstraction without parameter, becomes an entity.Error. Other missing
+// tokens, like a closing bracket, do not change the meaning of the tree and are ignored.
+func Lower(tree entity.ParseTree) (entity.Ast, error) {
+	return lowering{}.ast(tree)
+}
+
+// LowerRightAssociative lowers a parse tree of the underscore grammar the way the right
+// recursive Λs rule reads it: x_y_z is x_(y_z).
+func LowerRightAssociative(tree entity.ParseTree) (entity.Ast, error) {
+	return lowering{rightAssociative: true}.ast(tree)
+}
+
+type lowering struct {
+	rightAssociative bool
+}
+
+func (l lowering) ast(tree entity.ParseTree) (entity.Ast, error) {
+	term, err := l.lower(tree.Root())
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewAst(term), nil
+}
+
+func (l lowering) lower(n entity.Node) (entity.Term, error) {
+	if n.Token().Tag != entity.TERM || len(n.Child()) == 0 {
+		return nil, errMalformedTerm
+	}
+	if n.Child()[0].Token().Tag == entity.INVALID {
+		return &entity.Error{Span: n.Span()}, nil
+	}
+
+	head, span, tail, err := l.lowerPrimary(n.Child())
+	if err != nil || tail == nil {
+		return head, err
+	}
+	return l.lowerTerms(head, span, tail)
+}
+
+// lowerPrimary lowers the variable, abstraction or bracketed term the nodes start with, and
+// returns its span, which covers the brackets, with the Λs node following it, if any.
+// A bracketed term keeps the span of its inner term.
+func (l lowering) lowerPrimary(child []entity.Node) (entity.Term, entity.Span, entity.Node, error) {
+	var head entity.Term
+	var size int
+	switch child[0].Token().Tag {
+	case entity.VARIABLE:
+		head, size = &entity.Var{Name: fmt.Sprintf("%s", child[0].Token().Value), Span: child[0].Span()}, 1
+	case entity.LAMBDA:
+		if len(child) < 6 {
+			return nil, entity.Span{}, nil, errMalformedTerm
+		}
+		body, err := l.lower(child[5])
+		if err != nil {
+			return nil, entity.Span{}, nil, err
+		}
+		if head, err = desugarAbstraction(child[0], child[1:4], body, child[5].Span()); err != nil {
+			return nil, entity.Span{}, nil, err
+		}
+		size = 6
+	case entity.LET, entity.LETREC:
+		if len(child) < 7 {
+			return nil, entity.Span{}, nil, errMalformedTerm
+		}
+		value, err := l.lower(child[4])
+		if err != nil {
+			return nil, entity.Span{}, nil, err
+		}
+		body, err := l.lower(child[6])
+		if err != nil {
+			return nil, entity.Span{}, nil, err
+		}
+		if head, err = lowerLet(child, value, body); err != nil {
+			return nil, entity.Span{}, nil, err
+		}
+		size = 7
+	case entity.LEFT_BRACKET:
+		if len(child) < 3 {
+			return nil, entity.Span{}, nil, errMalformedTerm
+		}
+		var err error
+		if head, err = l.lower(child[1]); err != nil {
+			return nil, entity.Span{}, nil, err
+		}
+		size = 3
+	default:
+		return nil, entity.Span{}, nil, errMalformedTerm
+	}
+
+	span := child[0].Span().Join(child[size-1].Span())
+	switch len(child) {
+	case size:
+		return head, span, nil, nil
+	case size + 1:
+		return head, span, child[size], nil
+	default:
+		return nil, entity.Span{}, nil, errMalformedTerm
+	}
+}
+
+// lowerTerms applies head, read from span, to the terms of the Λs node n. The parser leaves Λs
+// without children when the lookahead is in FOLLOW(Λs) rather than the end of input.
+// In the juxtaposition grammar Λs holds the next argument followed by another Λs. In the underscore
+// grammar it holds a whole term after _, which is the argument when application associates to
+// the right, and otherwise the next argument followed by the Λs of the term.
+func (l lowering) lowerTerms(head entity.Term, span entity.Span, n entity.Node) (entity.Term, error) {
+	if n.Token().Tag != entity.TERMS {
+		return nil, errMalformedTerm
+	}
+	child := n.Child()
+	if len(child) == 0 || child[0].Token().Tag == entity.EPSILON {
+		return head, nil
+	}
+	if child[0].Token().Tag == entity.APPLICATION {
+		if len(child) != 2 || child[1].Token().Tag != entity.TERM || len(child[1].Child()) == 0 {
+			return nil, errMalformedTerm
+		}
+		if l.rightAssociative || child[1].Child()[0].Token().Tag == entity.INVALID {
+			arg, err := l.lower(child[1])
+			if err != nil {
+				return nil, err
+			}
+			return &entity.App{Fun: head, Arg: arg, Span: span.Join(n.Span())}, nil
+		}
+		child = child[1].Child()
+	}
+
+	arg, argSpan, tail, err := l.lowerPrimary(child)
+	if err != nil {
+		return nil, err
+	}
+	app := &entity.App{Fun: head, Arg: arg, Span: span.Join(argSpan)}
+	if tail == nil {
+		return app, nil
+	}
+	return l.lowerTerms(app, app.Span, tail)
+}
+
+// lowerLet binds the variable of let v vs = Λ in Λ, read from child, to value made a function
+// of the parameters vs.
+func lowerLet(child []entity.Node, value entity.Term, body entity.Term) (entity.Term, error) {
+	span := child[0].Span().Join(child[6].Span())
+	if child[1].Token().Tag == entity.INVALID {
+		return &entity.Error{Span: span}, nil
+	}
+	value, err := lowerParameters(child[2], value, child[4].Span())
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Let{
+		Name:      fmt.Sprintf("%s", child[1].Token().Value),
+		Value:     value,
+		Body:      body,
+		Recursive: child[0].Token().Tag == entity.LETREC,
+		Span:      span,
+	}, nil
+}
+
+// definition lowers the definition v vs = Λ of the node n.
+func (l lowering) definition(n entity.Node) (Definition, error) {
+	child := n.Child()
+	if n.Token().Tag != entity.DEFINITION || len(child) != 4 {
+		return Definition{}, errMalformedTerm
+	}
+	value, err := l.lower(child[3])
+	if err != nil {
+		return Definition{}, err
+	}
+	if value, err = lowerParameters(child[1], value, child[3].Span()); err != nil {
+		return Definition{}, err
+	}
+	return Definition{Name: fmt.Sprintf("%s", child[0].Token().Value), Term: value, Span: n.Span()}, nil
+}
+
+// lowerParameters makes body, which ends at end, a function of the parameters vs, if any.
+func lowerParameters(params entity.Node, body entity.Term, end entity.Span) (entity.Term, error) {
+	if len(params.Child()) != 3 {
+		return body, nil
+	}
+	return desugarAbstraction(params.Child()[0], params.Child(), body, end)
+}
+
+// desugarAbstraction nests one abstraction per parameter of λ v a vs . Λ around body, which ends
+// at end, params being the nodes v a vs. The outer abstraction starts at the λ, the inner ones at
+// their parameter.
+func desugarAbstraction(lambda entity.Node, params []entity.Node, body entity.Term, end entity.Span) (entity.Term, error) {
+	if params[0].Token().Tag == entity.INVALID {
+		return &entity.Error{Span: lambda.Span().Join(end)}, nil
+	}
+	names := []entity.Node{params[0]}
+	annotations := []entity.Node{params[1]}
+	for n := params[2]; len(n.Child()) == 3; n = n.Child()[2] {
+		if n.Token().Tag != entity.PARAMETERS {
+			return nil, errMalformedTerm
+		}
+		names = append(names, n.Child()[0])
+		annotations = append(annotations, n.Child()[1])
+	}
+
+	res := body
+	for i := len(names) - 1; i >= 0; i-- {
+		start := names[i].Span()
+		if i == 0 {
+			start = lambda.Span()
+		}
+		typ, err := lowerAnnotation(annotations[i])
+		if err != nil {
+			return nil, err
+		}
+		res = &entity.Abs{Param: fmt.Sprintf("%s", names[i].Token().Value), Type: typ, Body: res, Span: start.Join(end)}
+	}
+	return res, nil
+}
+
+// lowerAnnotation returns the type of the annotation a ⟶ ε | : τ, nil when there is none or it is
+// in error.
+func lowerAnnotation(n entity.Node) (entity.Type, error) {
+	if n.Token().Tag != entity.ANNOTATION {
+		return nil, errMalformedTerm
+	}
+	if len(n.Child()) != 2 {
+		return nil, nil
+	}
+	return lowerType(n.Child()[1])
+}
+
+// lowerType builds the type of the node τ, nil when it is in error.
+func lowerType(n entity.Node) (entity.Type, error) {
+	child := n.Child()
+	if n.Token().Tag != entity.TYPE {
+		return nil, errMalformedTerm
+	}
+	if len(child) == 0 || child[0].Token().Tag == entity.INVALID {
+		return nil, nil
+	}
+	var head entity.Type
+	var size int
+	switch child[0].Token().Tag {
+	case entity.VARIABLE:
+		head, size = &entity.BaseType{Name: fmt.Sprintf("%s", child[0].Token().Value), Span: child[0].Span()}, 1
+	case entity.LEFT_BRACKET:
+		if len(child) < 3 {
+			return nil, errMalformedTerm
+		}
+		var err error
+		if head, err = lowerType(child[1]); err != nil || head == nil {
+			return nil, err
+		}
+		size = 3
+	default:
+		return nil, errMalformedTerm
+	}
+	if len(child) == size {
+		return head, nil
+	}
+	arrow := child[size].Child()
+	if len(arrow) != 2 {
+		return head, nil
+	}
+	to, err := lowerType(arrow[1])
+	if err != nil || to == nil {
+		return nil, err
+	}
+	return &entity.Arrow{From: head, To: to, Span: child[0].Span().Join(n.Span())}, nil
+}
@@ -0,0 +1,157 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLower(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Lower variable",
+			scenario: happyFlowLowerVariable,
+		},
+		{
+			name:     "Happy flow. Lower application and abstraction",
+			scenario: happyFlowLowerApplicationAndAbstraction,
+		},
+		{
+			name:     "Happy flow. Lower brackets",
+			scenario: happyFlowLowerBrackets,
+		},
+		{
+			name:     "Happy flow. Lower spans of multiline term",
+			scenario: happyFlowLowerMultilineSpans,
+		},
+		{
+			name:     "Negative flow. Lower empty parse tree",
+			scenario: negativeFlowLowerEmptyParseTree,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowLowerVariable(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("x")
+	tree, err := parser.ParseTree(tk)
+	ast, err := Lower(tree)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.DeepEqual(t, ast.Term(), &entity.Var{Name: "x", Span: lineSpan(0, 1, 1, 2)})
+}
+
+func happyFlowLowerApplicationAndAbstraction(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("x_λy.x_y")
+	ast, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.DeepEqual(t, ast.Term(), &entity.App{
+		Fun: &entity.Var{Name: "x", Span: lineSpan(0, 1, 1, 2)},
+		Arg: &entity.Abs{
+			Param: "y",
+			Body: &entity.App{
+				Fun:  &entity.Var{Name: "x", Span: lineSpan(6, 7, 6, 7)},
+				Arg:  &entity.Var{Name: "y", Span: lineSpan(8, 9, 8, 9)},
+				Span: lineSpan(6, 9, 6, 9),
+			},
+			Span: lineSpan(2, 9, 3, 9),
+		},
+		Span: lineSpan(0, 9, 1, 9),
+	})
+}
+
+func happyFlowLowerBrackets(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("((λy.(y)))_z")
+	ast, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.DeepEqual(t, ast.Term(), &entity.App{
+		Fun: &entity.Abs{
+			Param: "y",
+			Body:  &entity.Var{Name: "y", Span: lineSpan(7, 8, 7, 8)},
+			Span:  lineSpan(2, 9, 3, 9),
+		},
+		Arg:  &entity.Var{Name: "z", Span: lineSpan(12, 13, 12, 13)},
+		Span: lineSpan(0, 13, 1, 13),
+	})
+}
+
+func happyFlowLowerMultilineSpans(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λf.\n  f_\n  -- argument\n  y")
+	tree, err := parser.ParseTree(tk)
+	ast, err := Lower(tree)
+
+	// assert
+	assert.Equal(t, err, nil)
+	body := ast.Term().(*entity.Abs).Body.(*entity.App)
+	assert.Equal(t, tree.Root().Span().String(), "1:1-4:4")
+	assert.Equal(t, ast.Term().(*entity.Abs).Span, tree.Root().Span())
+	assert.Equal(t, body.Fun.(*entity.Var).Span.String(), "2:3-2:4")
+	assert.Equal(t, body.Arg.(*entity.Var).Span, entity.Span{
+		Start: entity.Location{Offset: 26, Line: 4, Column: 3},
+		End:   entity.Location{Offset: 27, Line: 4, Column: 4},
+	})
+}
+
+func negativeFlowLowerEmptyParseTree(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tree := entity.NewParseTree(parser.(*lL1PredictableParser).NewNodeFromNonTerminal(entity.TERM))
+	_, err := Lower(tree)
+
+	// assert
+	assert.Equal(t, err, errMalformedTerm)
+}
+
+// lineSpan is the span between two offsets and two columns of the first line.
+func lineSpan(from int, to int, fromColumn int, toColumn int) entity.Span {
+	return entity.Span{
+		Start: entity.Location{Offset: from, Line: 1, Column: fromColumn},
+		End:   entity.Location{Offset: to, Line: 1, Column: toColumn},
+	}
+}
@@ -0,0 +1,130 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math-parser/pkg/entity"
+	"time"
+)
+
+const (
+	DEFAULT_MAX_STEPS = 10000
+	DEFAULT_MAX_SIZE  = 100000
+)
+
+var (
+	ErrStepLimitExceeded = errors.New("step limit exceeded")
+	ErrSizeLimitExceeded = errors.New("term size limit exceeded")
+)
+
+// NormalizationOptions bound a reduction. Zero values fall back to the normal order
+// strategy, DEFAULT_MAX_STEPS, DEFAULT_MAX_SIZE and no timeout.
+type NormalizationOptions struct {
+	Strategy ReductionStrategy
+	MaxSteps int
+	MaxSize  int
+	Timeout  time.Duration
+}
+
+// NormalizationError reports a reduction that was stopped before reaching a normal form.
+// Cause is ErrStepLimitExceeded, ErrSizeLimitExceeded or the error of the context.
+type NormalizationError struct {
+	Strategy string
+	Steps    int
+	Size     int
+	Cause    error
+}
+
+func (e *NormalizationError) Error() string {
+	return fmt.Sprintf("no normal form found within %d %s reduction steps: %v", e.Steps, e.Strategy, e.Cause)
+}
+
+func (e *NormalizationError) Unwrap() error {
+	return e.Cause
+}
+
+func (o NormalizationOptions) withDefaults() NormalizationOptions {
+	if o.Strategy == nil {
+		o.Strategy = NewNormalOrderStrategy()
+	}
+	if o.MaxSteps <= 0 {
+		o.MaxSteps = DEFAULT_MAX_STEPS
+	}
+	if o.MaxSize <= 0 {
+		o.MaxSize = DEFAULT_MAX_SIZE
+	}
+	return o
+}
+
+func (l *lL1PredictableParser) Normalize(ctx context.Context, ast entity.Ast, opts NormalizationOptions) (entity.Ast, error) {
+	opts = opts.withDefaults()
+	term, err := l.normalize(ctx, ast.Term(), opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	ast = entity.NewAst(term)
+
+	l.logging.Debugf("ast after %s reduction:\n%s", opts.Strategy.Name(), ast.Visualize())
+	return ast, nil
+}
+
+// normalize applies the steps of opts.Strategy until no redex is left, calling visit after each of them.
+func (l *lL1PredictableParser) normalize(ctx context.Context, term entity.Term, opts NormalizationOptions, visit func(entity.Term, *Redex) error) (entity.Term, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	for steps := 0; ; steps++ {
+		size := termSize(term)
+		fail := func(cause error) error {
+			return &NormalizationError{Strategy: opts.Strategy.Name(), Steps: steps, Size: size, Cause: cause}
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fail(err)
+		}
+		if size > opts.MaxSize {
+			return nil, fail(ErrSizeLimitExceeded)
+		}
+
+		next, redex, err := opts.Strategy.Step(term)
+		if err != nil {
+			return nil, err
+		}
+		if redex == nil {
+			return term, nil
+		}
+		if steps == opts.MaxSteps {
+			return nil, fail(ErrStepLimitExceeded)
+		}
+		term = next
+		if visit != nil {
+			if err = visit(term, redex); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// termSize counts the variables, abstractions and applications of t. Shared sub-terms are
+// counted once per occurrence, but measured only once.
+func termSize(t entity.Term) int {
+	return measureTerm(t, map[entity.Term]int{})
+}
+
+func measureTerm(t entity.Term, measured map[entity.Term]int) int {
+	if size, ok := measured[t]; ok {
+		return size
+	}
+	size := 1
+	switch t := t.(type) {
+	case *entity.Abs:
+		size += measureTerm(t.Body, measured)
+	case *entity.App:
+		size += measureTerm(t.Fun, measured) + measureTerm(t.Arg, measured)
+	}
+	measured[t] = size
+	return size
+}
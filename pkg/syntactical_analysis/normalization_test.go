@@ -0,0 +1,110 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"errors"
+	"gotest.tools/assert"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Normalize(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Normalize term with normal form",
+			scenario: happyFlowNormalizeTermWithNormalForm,
+		},
+		{
+			name:     "Negative flow. Normalize omega exhausts step budget",
+			scenario: negativeFlowNormalizeOmegaExhaustsStepBudget,
+		},
+		{
+			name:     "Negative flow. Normalize growing term exhausts size budget",
+			scenario: negativeFlowNormalizeGrowingTermExhaustsSizeBudget,
+		},
+		{
+			name:     "Negative flow. Normalize with cancelled context",
+			scenario: negativeFlowNormalizeWithCancelledContext,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowNormalizeTermWithNormalForm(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λf.λx.f_(f_x))_(λy.y)")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.Normalize(ctx, ast, NormalizationOptions{MaxSteps: 5})
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx.x)")
+}
+
+func negativeFlowNormalizeOmegaExhaustsStepBudget(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x_x)_(λx.x_x)")
+	ast, err := parser.Parse(tk)
+	_, err = parser.Normalize(ctx, ast, NormalizationOptions{MaxSteps: 42})
+
+	// assert
+	var normalizationErr *NormalizationError
+	assert.Assert(t, errors.As(err, &normalizationErr))
+	assert.Equal(t, normalizationErr.Steps, 42)
+	assert.Assert(t, errors.Is(err, ErrStepLimitExceeded))
+}
+
+func negativeFlowNormalizeGrowingTermExhaustsSizeBudget(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x_x_x)_(λx.x_x_x)")
+	ast, err := parser.Parse(tk)
+	_, err = parser.Normalize(ctx, ast, NormalizationOptions{MaxSize: 100})
+
+	// assert
+	assert.Assert(t, errors.Is(err, ErrSizeLimitExceeded))
+}
+
+func negativeFlowNormalizeWithCancelledContext(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x_x)_(λx.x_x)")
+	ast, err := parser.Parse(tk)
+	_, err = parser.Normalize(cancelled, ast, NormalizationOptions{})
+
+	// assert
+	assert.Assert(t, errors.Is(err, context.Canceled))
+}
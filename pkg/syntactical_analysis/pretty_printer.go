@@ -0,0 +1,193 @@
+package syntactical_analyzer
+
+import (
+	"math-parser/pkg/entity"
+	"strings"
+	"unicode/utf8"
+)
+
+const DEFAULT_INDENT = 2
+
+// Notation tells how the pretty printer writes a term. It matches the Config of the parser
+// expected to read the output back: Juxtaposition and RightAssociative have the same meaning,
+// the latter being ignored together with juxtaposition, which is always left-associative.
+// Ascii writes \ instead of λ and Sugar writes λx y.M instead of λx.λy.M.
+type Notation struct {
+	Juxtaposition    bool
+	RightAssociative bool
+	Ascii            bool
+	Sugar            bool
+}
+
+// PrintOptions configure Print. A term longer than Width is broken over several lines,
+// the lines of its sub-terms being indented by Indent spaces, DEFAULT_INDENT if zero.
+// A zero Width prints every term on a single line.
+type PrintOptions struct {
+	Notation
+	Width  int
+	Indent int
+}
+
+// Print writes the term of ast with as few brackets as the notation allows to read it back:
+// parsing the output with the matching Config yields a term alpha-equivalent to the input.
+func Print(ast entity.Ast, opts PrintOptions) (string, error) {
+	if opts.Indent <= 0 {
+		opts.Indent = DEFAULT_INDENT
+	}
+	return printer{opts: opts}.write(ast.Term(), true, 0)
+}
+
+type printer struct {
+	opts PrintOptions
+}
+
+func (p printer) rightAssociative() bool {
+	return p.opts.RightAssociative && !p.opts.Juxtaposition
+}
+
+func (p printer) lambda() string {
+	if p.opts.Ascii {
+		return "\\"
+	}
+	return LAMBDA
+}
+
+// head prints the binders of an abstraction and returns its body, which is the first
+// non-abstraction under a when the notation has sugar.
+func (p printer) head(a *entity.Abs) (string, entity.Term) {
+	params := []string{p.param(a)}
+	body := a.Body
+	for p.opts.Sugar {
+		inner, ok := body.(*entity.Abs)
+		if !ok {
+			break
+		}
+		params = append(params, p.param(inner))
+		body = inner.Body
+	}
+	return p.lambda() + strings.Join(params, " ") + ABSTRACTION, body
+}
+
+// param prints the parameter of a together with its type annotation, if any.
+func (p printer) param(a *entity.Abs) string {
+	if a.Type == nil {
+		return a.Param
+	}
+	res := a.Param + COLON + a.Type.String()
+	if p.opts.Ascii {
+		res = strings.ReplaceAll(res, ARROW, "->")
+	}
+	return res
+}
+
+// write prints t starting at column indent. An abstraction or a let binding extends as far right
+// as possible, so it is bracketed unless nothing follows it (last). A term that does not fit in the
+// width gets the body of an abstraction, the arguments of an application, or the value and the
+// body of a let binding, on lines of their own.
+func (p printer) write(t entity.Term, last bool, indent int) (string, error) {
+	broken := false
+	if p.opts.Width > 0 {
+		flat := printer{opts: p.opts}
+		flat.opts.Width = 0
+		res, err := flat.write(t, last, indent)
+		if err != nil || indent+utf8.RuneCountInString(res) <= p.opts.Width {
+			return res, err
+		}
+		broken = true
+	}
+	inner := indent + p.opts.Indent
+	switch t := t.(type) {
+	case *entity.Var:
+		return t.Name, nil
+	case *entity.Abs:
+		head, body := p.head(t)
+		res, err := p.write(body, true, inner)
+		if err != nil {
+			return "", err
+		}
+		if broken {
+			head += "\n" + strings.Repeat(" ", inner)
+		}
+		res = head + res
+		if !last {
+			res = "(" + res + ")"
+		}
+		return res, nil
+	case *entity.App:
+		fun, args := p.spine(t)
+		res, err := p.operand(fun, false, p.rightAssociative(), indent)
+		if err != nil {
+			return "", err
+		}
+		separator := APPLICATION
+		if p.opts.Juxtaposition {
+			separator = " "
+		}
+		if broken {
+			separator = "\n" + strings.Repeat(" ", inner)
+			if !p.opts.Juxtaposition {
+				separator += APPLICATION
+				inner++
+			}
+		}
+		for i, arg := range args {
+			s, err := p.operand(arg, last && i == len(args)-1, !p.rightAssociative(), inner)
+			if err != nil {
+				return "", err
+			}
+			res += separator + s
+		}
+		return res, nil
+	case *entity.Let:
+		value, err := p.write(t.Value, true, inner)
+		if err != nil {
+			return "", err
+		}
+		body, err := p.write(t.Body, true, indent+utf8.RuneCountInString(IN)+1)
+		if err != nil {
+			return "", err
+		}
+		res := letKeyword(t) + " " + t.Name + " " + EQUALS
+		if broken {
+			res += "\n" + strings.Repeat(" ", inner) + value + "\n" + strings.Repeat(" ", indent)
+		} else {
+			res += " " + value + " "
+		}
+		res += IN + " " + body
+		if !last {
+			res = "(" + res + ")"
+		}
+		return res, nil
+	default:
+		return "", errMalformedTerm
+	}
+}
+
+// operand prints the function or an argument of an application, bracketed if it is itself an
+// application on the side the notation does not associate to.
+func (p printer) operand(t entity.Term, last bool, bracketApp bool, indent int) (string, error) {
+	if _, ok := t.(*entity.App); ok && bracketApp {
+		res, err := p.write(t, true, indent+1)
+		return "(" + res + ")", err
+	}
+	return p.write(t, last, indent)
+}
+
+// spine splits an application into the function and the arguments it is applied to, in the
+// order they are written. Under right associativity only the outermost application is split.
+func (p printer) spine(t *entity.App) (entity.Term, []entity.Term) {
+	if p.rightAssociative() {
+		return t.Fun, []entity.Term{t.Arg}
+	}
+	var args []entity.Term
+	var fun entity.Term = t
+	for {
+		app, ok := fun.(*entity.App)
+		if !ok {
+			break
+		}
+		args = append([]entity.Term{app.Arg}, args...)
+		fun = app.Fun
+	}
+	return fun, args
+}
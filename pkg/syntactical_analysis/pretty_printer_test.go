@@ -0,0 +1,190 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"math/rand"
+	"testing"
+)
+
+func TestPrint(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Print minimal brackets",
+			scenario: happyFlowPrintMinimalBrackets,
+		},
+		{
+			name:     "Happy flow. Print right-associative application",
+			scenario: happyFlowPrintRightAssociative,
+		},
+		{
+			name:     "Happy flow. Print ASCII juxtaposition with sugar",
+			scenario: happyFlowPrintAsciiJuxtapositionWithSugar,
+		},
+		{
+			name:     "Happy flow. Break long terms over indented lines",
+			scenario: happyFlowPrintLineBreaks,
+		},
+		{
+			name:     "Happy flow. Read printed terms back in every notation",
+			scenario: happyFlowPrintRoundTrip,
+		},
+		{
+			name:     "Negative flow. Reject partial tree",
+			scenario: negativeFlowPrintPartialTree,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowPrintMinimalBrackets(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("((λx.(x))_(((a_b))_(λy.y)))")
+	ast, err := parser.Parse(tk)
+	res, err := Print(ast, PrintOptions{})
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx.x)_(a_b_λy.y)")
+}
+
+func happyFlowPrintRightAssociative(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x)_(a_b_λy.y)")
+	ast, err := parser.Parse(tk)
+	res, err := Print(ast, PrintOptions{Notation: Notation{RightAssociative: true}})
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx.x)_(a_b)_λy.y")
+}
+
+func happyFlowPrintAsciiJuxtapositionWithSugar(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λf.λx.f_(f_x))_(λy.y)_a")
+	ast, err := parser.Parse(tk)
+	res, err := Print(ast, PrintOptions{Notation: Notation{Juxtaposition: true, Ascii: true, Sugar: true}})
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, `(\f x.f (f x)) (\y.y) a`)
+}
+
+func happyFlowPrintLineBreaks(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("λsucc.λzero.succ (succ (succ (succ zero))) (λy.y)")
+	ast, err := parser.Parse(tk)
+	res, err := Print(ast, PrintOptions{Notation: Notation{Juxtaposition: true, Sugar: true}, Width: 30})
+	tk, _ = analyzer.Tokenize(res)
+	again, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, ""+
+		"λsucc zero.\n"+
+		"  succ\n"+
+		"    (succ (succ (succ zero)))\n"+
+		"    λy.y")
+	assert.Assert(t, AlphaEquivalent(ast, again))
+}
+
+func happyFlowPrintRoundTrip(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	random := rand.New(rand.NewSource(1))
+	notations := []Notation{
+		{},
+		{RightAssociative: true},
+		{Juxtaposition: true},
+		{Ascii: true, Sugar: true},
+		{Juxtaposition: true, Ascii: true, Sugar: true},
+	}
+
+	for i := 0; i < 200; i++ {
+		ast := entity.NewAst(randomTerm(random, 5))
+		for _, notation := range notations {
+			parser := NewConfiguredLL1PredictableParser(ctx, Config{
+				Juxtaposition:    notation.Juxtaposition,
+				RightAssociative: notation.RightAssociative,
+			})
+			for _, width := range []int{0, 12} {
+				// act
+				res, err := Print(ast, PrintOptions{Notation: notation, Width: width})
+				tk, _ := analyzer.Tokenize(res)
+				again, err := parser.Parse(tk)
+
+				// assert
+				assert.Equal(t, err, nil, res)
+				assert.Assert(t, AlphaEquivalent(ast, again), res)
+			}
+		}
+	}
+}
+
+func negativeFlowPrintPartialTree(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("f_)")
+	ast, _ := parser.Parse(tk)
+	_, err := Print(ast, PrintOptions{})
+
+	// assert
+	assert.Equal(t, err, errMalformedTerm)
+}
+
+// randomTerm builds a term of at most the given depth over the variables x, y and z.
+func randomTerm(random *rand.Rand, depth int) entity.Term {
+	names := []string{"x", "y", "z"}
+	if depth == 0 {
+		return entity.NewVar(names[random.Intn(len(names))])
+	}
+	switch random.Intn(3) {
+	case 0:
+		return entity.NewVar(names[random.Intn(len(names))])
+	case 1:
+		return entity.NewAbs(names[random.Intn(len(names))], randomTerm(random, depth-1))
+	default:
+		return entity.NewApp(randomTerm(random, depth-1), randomTerm(random, depth-1))
+	}
+}
@@ -0,0 +1,205 @@
+package syntactical_analyzer
+
+import (
+	"fmt"
+	"math-parser/pkg/entity"
+	"math/big"
+)
+
+// The primitives are free variables the lexer reads with Config.Primitives: integer literals,
+// like 42 or -7, and the operators below, which a δ step evaluates natively.
+const (
+	PLUS  = "+"
+	MINUS = "-"
+	TIMES = "*"
+	EQUAL = "=="
+	IF    = "if"
+)
+
+// arity is the number of operands of each operator.
+var arity = map[string]int{PLUS: 2, MINUS: 2, TIMES: 2, EQUAL: 2, IF: 3}
+
+// NewPrimitiveStrategy returns the strategy of the given name that also contracts δ-redexes:
+// + - * on integer literals give a literal, == gives a Church boolean, and if c x y gives x or y
+// once c is a Church boolean. The operands a primitive needs are reduced first, even by lazy
+// strategies, while the branches of if are left alone until one of them is selected.
+func NewPrimitiveStrategy(name string) (ReductionStrategy, error) {
+	strategy, err := NewReductionStrategy(name)
+	if err != nil {
+		return nil, err
+	}
+	res := *strategy.(*reductionStrategy)
+	res.delta = true
+	return &res, nil
+}
+
+// DeltaReduce evaluates the applications of primitives to literals in a single bottom-up sweep.
+func (l *lL1PredictableParser) DeltaReduce(ast entity.Ast) (entity.Ast, error) {
+	if err := checkPrimitiveBinders(ast.Term()); err != nil {
+		return nil, err
+	}
+	ast = entity.NewAst(deltaReduce(ast.Term()))
+
+	l.logging.Debugf("ast after delta-reduction:\n%s", ast.Visualize())
+	return ast, nil
+}
+
+func deltaReduce(t entity.Term) entity.Term {
+	switch t := t.(type) {
+	case *entity.Abs:
+		return t.Rebuild(t.Param, deltaReduce(t.Body))
+	case *entity.App:
+		app := t.Rebuild(deltaReduce(t.Fun), deltaReduce(t.Arg))
+		if res, ok := deltaContract(app); ok {
+			return res
+		}
+		return app
+	default:
+		return t
+	}
+}
+
+// primitive steps app when it applies a primitive to all of its operands: it contracts app if the
+// operands are values, otherwise it reduces the first operand that is not. It returns a nil redex
+// when app is not such an application or its operands are stuck.
+func (s *reductionStrategy) primitive(app *entity.App, pos Position) (entity.Term, *Redex, error) {
+	op, operands, ok := primitiveSpine(app)
+	if !ok {
+		return app, nil, nil
+	}
+	if res, ok := deltaContract(app); ok {
+		return res, &Redex{Position: pos, Rule: DELTA_RULE, Span: app.Span}, nil
+	}
+	strict := operands
+	if op == IF {
+		strict = operands[:1]
+	}
+	for i, operand := range strict {
+		if isValue(op, operand) {
+			continue
+		}
+		depth := len(operands) - 1 - i
+		operandPos := pos
+		for j := 0; j < depth; j++ {
+			operandPos = operandPos.Child(0)
+		}
+		res, redex, err := s.step(operand, operandPos.Child(1))
+		if err != nil || redex == nil {
+			return app, nil, err
+		}
+		return withOperand(app, depth, res), redex, nil
+	}
+	return app, nil, nil
+}
+
+// primitiveSpine returns the operator of app and its operands when app applies a primitive to
+// exactly as many operands as it takes.
+func primitiveSpine(app *entity.App) (string, []entity.Term, bool) {
+	var operands []entity.Term
+	var fun entity.Term = app
+	for {
+		inner, ok := fun.(*entity.App)
+		if !ok {
+			break
+		}
+		operands = append([]entity.Term{inner.Arg}, operands...)
+		fun = inner.Fun
+	}
+	v, ok := fun.(*entity.Var)
+	if !ok || arity[v.Name] != len(operands) {
+		return "", nil, false
+	}
+	return v.Name, operands, true
+}
+
+// withOperand replaces the operand of app found under depth functions with operand.
+func withOperand(app *entity.App, depth int, operand entity.Term) *entity.App {
+	if depth == 0 {
+		return app.Rebuild(app.Fun, operand)
+	}
+	return app.Rebuild(withOperand(app.Fun.(*entity.App), depth-1, operand), app.Arg)
+}
+
+// isValue tells whether operand is reduced enough for op: a literal for arithmetic and
+// comparison, a Church boolean for the condition of if.
+func isValue(op string, operand entity.Term) bool {
+	if op == IF {
+		_, ok := decodeBool(operand)
+		return ok
+	}
+	_, ok := literal(operand)
+	return ok
+}
+
+// deltaContract evaluates app when it is a primitive applied to values.
+func deltaContract(app *entity.App) (entity.Term, bool) {
+	op, operands, ok := primitiveSpine(app)
+	if !ok {
+		return nil, false
+	}
+	if op == IF {
+		b, ok := decodeBool(operands[0])
+		if !ok {
+			return nil, false
+		}
+		if b.(bool) {
+			return operands[1], true
+		}
+		return operands[2], true
+	}
+	x, ok := literal(operands[0])
+	if !ok {
+		return nil, false
+	}
+	y, ok := literal(operands[1])
+	if !ok {
+		return nil, false
+	}
+	res := new(big.Int)
+	switch op {
+	case PLUS:
+		res.Add(x, y)
+	case MINUS:
+		res.Sub(x, y)
+	case TIMES:
+		res.Mul(x, y)
+	case EQUAL:
+		b, _ := encode(x.Cmp(y) == 0, Shape{Kind: BOOL})
+		return b, true
+	}
+	return &entity.Var{Name: res.String(), Span: app.Span}, true
+}
+
+// literal returns the integer t stands for when it is an integer literal.
+func literal(t entity.Term) (*big.Int, bool) {
+	v, ok := t.(*entity.Var)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Int).SetString(v.Name, 10)
+}
+
+// isPrimitive tells whether name is a literal or an operator.
+func isPrimitive(name string) bool {
+	_, ok := new(big.Int).SetString(name, 10)
+	return ok || arity[name] != 0
+}
+
+// checkPrimitiveBinders rejects the terms with an abstraction binding a primitive, which would
+// otherwise be evaluated in place of the bound variable.
+func checkPrimitiveBinders(t entity.Term) error {
+	switch t := t.(type) {
+	case *entity.Abs:
+		if isPrimitive(t.Param) {
+			return fmt.Errorf("%v: the primitive %s cannot be bound", entity.SpanOf(t).Start, t.Param)
+		}
+		return checkPrimitiveBinders(t.Body)
+	case *entity.App:
+		if err := checkPrimitiveBinders(t.Fun); err != nil {
+			return err
+		}
+		return checkPrimitiveBinders(t.Arg)
+	default:
+		return nil
+	}
+}
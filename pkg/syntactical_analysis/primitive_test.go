@@ -0,0 +1,160 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestPrimitiveStrategy(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Evaluate arithmetic natively",
+			scenario: happyFlowPrimitiveArithmetic,
+		},
+		{
+			name:     "Happy flow. Select branch of if without reducing the other",
+			scenario: happyFlowPrimitiveIf,
+		},
+		{
+			name:     "Happy flow. Trace delta steps",
+			scenario: happyFlowPrimitiveTrace,
+		},
+		{
+			name:     "Happy flow. Delta-reduce in a single sweep",
+			scenario: happyFlowDeltaReduce,
+		},
+		{
+			name:     "Negative flow. Reject bound primitive",
+			scenario: negativeFlowPrimitiveBound,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func newPrimitiveAnalyzer(ctx context.Context) lexical_analysis.LexicalAnalyzer {
+	config := lexical_analysis.DefaultConfig()
+	config.Primitives = true
+	return lexical_analysis.NewLexicalAnalyzer(ctx, lexical_analysis.NewConfiguredAutomata(config))
+}
+
+func happyFlowPrimitiveArithmetic(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	analyzer := newPrimitiveAnalyzer(ctx)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	var tests = []struct {
+		input    string
+		strategy string
+		res      string
+	}{
+		{input: "+ 2 3", strategy: NORMAL_ORDER, res: "5"},
+		{input: "* 123456789123456789 1000", strategy: NORMAL_ORDER, res: "123456789123456789000"},
+		{input: "- 2 (* 3 4)", strategy: APPLICATIVE_ORDER, res: "-10"},
+		{input: "(λx.+ x x) (- 7 -7)", strategy: CALL_BY_NAME, res: "28"},
+		{input: "(λx.+ x x) (- 7 -7)", strategy: CALL_BY_NEED, res: "28"},
+		{input: "== (+ 1 1) 2", strategy: CALL_BY_VALUE, res: "λt.λf.t"},
+		{input: "λx.+ 1 2", strategy: NORMAL_ORDER, res: "λx.3"},
+		{input: "λx.+ 1 2", strategy: CALL_BY_NAME, res: "λx.+ 1 2"},
+		{input: "+ x 1", strategy: NORMAL_ORDER, res: "+ x 1"},
+	}
+
+	for _, test := range tests {
+		// act
+		tk, _ := analyzer.Tokenize(test.input)
+		ast, err := parser.Parse(tk)
+		strategy, err := NewPrimitiveStrategy(test.strategy)
+		normal, err := parser.Reduce(ast, strategy)
+		res, err := parser.Unparse(normal)
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res, test.res, test.input)
+	}
+}
+
+func happyFlowPrimitiveIf(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	analyzer := newPrimitiveAnalyzer(ctx)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	for _, name := range []string{NORMAL_ORDER, APPLICATIVE_ORDER, CALL_BY_NAME, CALL_BY_VALUE, CALL_BY_NEED} {
+		// act
+		tk, _ := analyzer.Tokenize("if (== 1 2) ((λx.x x) (λx.x x)) (* 6 7)")
+		ast, err := parser.Parse(tk)
+		strategy, err := NewPrimitiveStrategy(name)
+		normal, err := parser.Reduce(ast, strategy)
+		res, err := parser.Decode(normal, Shape{Kind: RAW})
+
+		// assert
+		assert.Equal(t, err, nil)
+		assert.Equal(t, res, "42", name)
+	}
+}
+
+func happyFlowPrimitiveTrace(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	analyzer := newPrimitiveAnalyzer(ctx)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.* x x) (+ 1 2)")
+	ast, err := parser.Parse(tk)
+	strategy, err := NewPrimitiveStrategy(CALL_BY_NAME)
+	steps, err := parser.Trace(ast, strategy)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(steps), 5)
+	assert.Equal(t, steps[1].Redex.Rule, BETA_RULE)
+	assert.Equal(t, steps[2].Redex.Rule, DELTA_RULE)
+	assert.Equal(t, steps[2].Redex.Position.String(), "0.1")
+	assert.Equal(t, steps[2].Term, "* 3 (+ 1 2)")
+	assert.Equal(t, steps[4].Term, "9")
+}
+
+func happyFlowDeltaReduce(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	analyzer := newPrimitiveAnalyzer(ctx)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λy.+_(*_2_3)_y)_(-_1_1)")
+	ast, err := parser.Parse(tk)
+	ast, err = parser.DeltaReduce(ast)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "((λy.((+_6)_y))_0)")
+}
+
+func negativeFlowPrimitiveBound(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	analyzer := newPrimitiveAnalyzer(ctx)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+	strategy, _ := NewPrimitiveStrategy(NORMAL_ORDER)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λ+.+ 1 2) (λx y.x)")
+	ast, _ := parser.Parse(tk)
+	_, err := parser.Reduce(ast, strategy)
+	_, deltaErr := parser.DeltaReduce(ast)
+
+	// assert
+	assert.ErrorContains(t, err, "1:2: the primitive + cannot be bound")
+	assert.Equal(t, deltaErr.Error(), "1:2: the primitive + cannot be bound")
+}
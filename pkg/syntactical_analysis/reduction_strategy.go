@@ -0,0 +1,211 @@
+package syntactical_analyzer
+
+import (
+	"fmt"
+	"math-parser/pkg/entity"
+)
+
+const (
+	NORMAL_ORDER      = "normal"
+	APPLICATIVE_ORDER = "applicative"
+	CALL_BY_NAME      = "cbn"
+	CALL_BY_VALUE     = "cbv"
+	CALL_BY_NEED      = "cbneed"
+	ETA               = "eta"
+	BETA_ETA          = "betaeta"
+)
+
+// ReductionStrategy decides which redex of a term is contracted next.
+type ReductionStrategy interface {
+	Name() string
+	// Step contracts one redex of n and describes it, the redex is nil when there was none left.
+	Step(t entity.Term) (entity.Term, *Redex, error)
+}
+
+type reductionStrategy struct {
+	name string
+	// beta, eta and delta select the rules the strategy contracts, delta standing for the
+	// primitives of NewPrimitiveStrategy.
+	beta  bool
+	eta   bool
+	delta bool
+	// innermost strategies reduce the sub-terms of a redex before contracting it.
+	innermost bool
+	// weak strategies never reduce inside an abstraction.
+	weak bool
+	// lazy strategies never reduce the arguments of an application.
+	lazy bool
+	// sharing records every rewritten node, so that a term shared between several
+	// places is reduced only once. It stays nil for strategies without sharing.
+	sharing map[entity.Term]entity.Term
+}
+
+// NewNormalOrderStrategy contracts the leftmost outermost redex, reducing under abstractions.
+func NewNormalOrderStrategy() ReductionStrategy {
+	return &reductionStrategy{name: NORMAL_ORDER, beta: true}
+}
+
+// NewApplicativeOrderStrategy contracts the leftmost innermost redex, reducing under abstractions.
+func NewApplicativeOrderStrategy() ReductionStrategy {
+	return &reductionStrategy{name: APPLICATIVE_ORDER, beta: true, innermost: true}
+}
+
+// NewCallByNameStrategy contracts the head redex until the term is in weak head normal form.
+func NewCallByNameStrategy() ReductionStrategy {
+	return &reductionStrategy{name: CALL_BY_NAME, beta: true, weak: true, lazy: true}
+}
+
+// NewCallByValueStrategy reduces the arguments to values before contracting, never under abstractions.
+func NewCallByValueStrategy() ReductionStrategy {
+	return &reductionStrategy{name: CALL_BY_VALUE, beta: true, innermost: true, weak: true}
+}
+
+// NewCallByNeedStrategy reduces like call-by-name, but a substituted argument is shared
+// between its occurrences and reduced at most once. The sharing table lives in the
+// strategy, so a new strategy should be used for every term.
+func NewCallByNeedStrategy() ReductionStrategy {
+	return &reductionStrategy{name: CALL_BY_NEED, beta: true, weak: true, lazy: true, sharing: map[entity.Term]entity.Term{}}
+}
+
+// NewEtaStrategy contracts the leftmost outermost eta-redex λx.M_x, where x is not free in M.
+func NewEtaStrategy() ReductionStrategy {
+	return &reductionStrategy{name: ETA, eta: true}
+}
+
+// NewBetaEtaStrategy contracts beta- and eta-redexes in normal order, leading to the βη-normal form.
+func NewBetaEtaStrategy() ReductionStrategy {
+	return &reductionStrategy{name: BETA_ETA, beta: true, eta: true}
+}
+
+func NewReductionStrategy(name string) (ReductionStrategy, error) {
+	constructor, ok := map[string]func() ReductionStrategy{
+		NORMAL_ORDER:      NewNormalOrderStrategy,
+		APPLICATIVE_ORDER: NewApplicativeOrderStrategy,
+		CALL_BY_NAME:      NewCallByNameStrategy,
+		CALL_BY_VALUE:     NewCallByValueStrategy,
+		CALL_BY_NEED:      NewCallByNeedStrategy,
+		ETA:               NewEtaStrategy,
+		BETA_ETA:          NewBetaEtaStrategy,
+	}[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown reduction strategy %s", name)
+	}
+	return constructor(), nil
+}
+
+func (s *reductionStrategy) Name() string {
+	return s.name
+}
+
+func (s *reductionStrategy) Step(t entity.Term) (entity.Term, *Redex, error) {
+	if s.sharing != nil {
+		t = s.share(t)
+	}
+	if s.delta {
+		if err := checkPrimitiveBinders(t); err != nil {
+			return t, nil, err
+		}
+	}
+	res, redex, err := s.step(t, Position{})
+	if err != nil || redex == nil {
+		return t, nil, err
+	}
+	if s.sharing != nil {
+		res = s.share(res)
+	}
+	return res, redex, nil
+}
+
+func (s *reductionStrategy) step(t entity.Term, pos Position) (entity.Term, *Redex, error) {
+	res, redex, err := s.reduce(t, pos)
+	if redex != nil && s.sharing != nil {
+		s.sharing[t] = res
+	}
+	return res, redex, err
+}
+
+func (s *reductionStrategy) reduce(t entity.Term, pos Position) (entity.Term, *Redex, error) {
+	switch t := t.(type) {
+	case *entity.Var:
+		return t, nil, nil
+	case *entity.Abs:
+		if s.eta {
+			if res, ok := etaContract(t); ok {
+				return res, &Redex{Position: pos, Rule: ETA_RULE, Span: t.Span}, nil
+			}
+		}
+		if s.weak {
+			return t, nil, nil
+		}
+		body, redex, err := s.step(t.Body, pos.Child(0))
+		if err != nil || redex == nil {
+			return t, nil, err
+		}
+		return t.Rebuild(t.Param, body), redex, nil
+	case *entity.App:
+		if s.delta {
+			if res, redex, err := s.primitive(t, pos); err != nil || redex != nil {
+				return res, redex, err
+			}
+		}
+		if abs, ok := t.Fun.(*entity.Abs); ok && s.beta && !s.innermost {
+			return contract(t, abs, pos)
+		}
+		if res, redex, err := s.step(t.Fun, pos.Child(0)); err != nil || redex != nil {
+			return t.Rebuild(res, t.Arg), redex, err
+		}
+		if !s.lazy {
+			if res, redex, err := s.step(t.Arg, pos.Child(1)); err != nil || redex != nil {
+				return t.Rebuild(t.Fun, res), redex, err
+			}
+		}
+		if abs, ok := t.Fun.(*entity.Abs); ok && s.beta {
+			return contract(t, abs, pos)
+		}
+		return t, nil, nil
+	default:
+		return nil, nil, errMalformedTerm
+	}
+}
+
+// share replaces every node of t that has already been rewritten with its latest version.
+func (s *reductionStrategy) share(t entity.Term) entity.Term {
+	for {
+		res, ok := s.sharing[t]
+		if !ok {
+			break
+		}
+		t = res
+	}
+
+	var res entity.Term
+	switch t := t.(type) {
+	case *entity.Abs:
+		if body := s.share(t.Body); body != t.Body {
+			res = t.Rebuild(t.Param, body)
+		}
+	case *entity.App:
+		fun, arg := s.share(t.Fun), s.share(t.Arg)
+		if fun != t.Fun || arg != t.Arg {
+			res = t.Rebuild(fun, arg)
+		}
+	}
+	if res == nil {
+		return t
+	}
+	s.sharing[t] = res
+	return res
+}
+
+// contract rewrites the redex app, whose function is abs, at pos. When the substitution would
+// capture a free variable of the argument, only the offending binders of abs are renamed (an
+// alpha step), and the beta step happens on the next call.
+func contract(app *entity.App, abs *entity.Abs, pos Position) (entity.Term, *Redex, error) {
+	redex := &Redex{Position: pos, Span: app.Span}
+	if body := avoidCapture(abs.Body, abs.Param, freeVariables(app.Arg)); body != abs.Body {
+		redex.Rule = ALPHA_RULE
+		return app.Rebuild(abs.Rebuild(abs.Param, body), app.Arg), redex, nil
+	}
+	redex.Rule = BETA_RULE
+	return substitute(abs.Body, abs.Param, app.Arg), redex, nil
+}
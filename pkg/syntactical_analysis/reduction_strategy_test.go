@@ -0,0 +1,189 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"errors"
+	"gotest.tools/assert"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+const omegaArgument = "(λx.y)_((λx.x_x)_(λx.x_x))"
+
+func TestLL1PredictableParser_Reduce(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Normal order skips a divergent argument",
+			scenario: happyFlowNormalOrderSkipsDivergentArgument,
+		},
+		{
+			name:     "Happy flow. Call-by-name skips a divergent argument",
+			scenario: happyFlowCallByNameSkipsDivergentArgument,
+		},
+		{
+			name:     "Happy flow. Call-by-need skips a divergent argument",
+			scenario: happyFlowCallByNeedSkipsDivergentArgument,
+		},
+		{
+			name:     "Negative flow. Applicative order diverges on a divergent argument",
+			scenario: negativeFlowApplicativeOrderDivergesOnDivergentArgument,
+		},
+		{
+			name:     "Negative flow. Call-by-value diverges on a divergent argument",
+			scenario: negativeFlowCallByValueDivergesOnDivergentArgument,
+		},
+		{
+			name:     "Happy flow. Normal order reduces under abstraction",
+			scenario: happyFlowNormalOrderReducesUnderAbstraction,
+		},
+		{
+			name:     "Happy flow. Applicative order reduces under abstraction",
+			scenario: happyFlowApplicativeOrderReducesUnderAbstraction,
+		},
+		{
+			name:     "Happy flow. Call-by-name stops at weak head normal form",
+			scenario: happyFlowCallByNameStopsAtWeakHeadNormalForm,
+		},
+		{
+			name:     "Happy flow. Call-by-need shares the argument",
+			scenario: happyFlowCallByNeedSharesArgument,
+		},
+		{
+			name:     "Happy flow. Call-by-value reduces the argument first",
+			scenario: happyFlowCallByValueReducesArgumentFirst,
+		},
+		{
+			name:     "Negative flow. Unknown strategy",
+			scenario: negativeFlowUnknownStrategy,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func reduceExpression(expression string, strategyName string) (string, error) {
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	strategy, err := NewReductionStrategy(strategyName)
+	if err != nil {
+		return "", err
+	}
+	tk, err := analyzer.Tokenize(expression)
+	if err != nil {
+		return "", err
+	}
+	ast, err := parser.Parse(tk)
+	if err != nil {
+		return "", err
+	}
+	if ast, err = parser.Reduce(ast, strategy); err != nil {
+		return "", err
+	}
+	return parser.Unparse(ast)
+}
+
+func happyFlowNormalOrderSkipsDivergentArgument(t *testing.T) {
+	// act
+	res, err := reduceExpression(omegaArgument, NORMAL_ORDER)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "y")
+}
+
+func happyFlowCallByNameSkipsDivergentArgument(t *testing.T) {
+	// act
+	res, err := reduceExpression(omegaArgument, CALL_BY_NAME)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "y")
+}
+
+func happyFlowCallByNeedSkipsDivergentArgument(t *testing.T) {
+	// act
+	res, err := reduceExpression(omegaArgument, CALL_BY_NEED)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "y")
+}
+
+func negativeFlowApplicativeOrderDivergesOnDivergentArgument(t *testing.T) {
+	// act
+	_, err := reduceExpression(omegaArgument, APPLICATIVE_ORDER)
+
+	// assert
+	assert.Assert(t, errors.Is(err, ErrStepLimitExceeded))
+}
+
+func negativeFlowCallByValueDivergesOnDivergentArgument(t *testing.T) {
+	// act
+	_, err := reduceExpression(omegaArgument, CALL_BY_VALUE)
+
+	// assert
+	assert.Assert(t, errors.Is(err, ErrStepLimitExceeded))
+}
+
+func happyFlowNormalOrderReducesUnderAbstraction(t *testing.T) {
+	// act
+	res, err := reduceExpression("λx.(λy.y)_x", NORMAL_ORDER)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx.x)")
+}
+
+func happyFlowApplicativeOrderReducesUnderAbstraction(t *testing.T) {
+	// act
+	res, err := reduceExpression("(λx.x_x)_λz.(λy.y)_z", APPLICATIVE_ORDER)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λz.z)")
+}
+
+func happyFlowCallByNameStopsAtWeakHeadNormalForm(t *testing.T) {
+	// act
+	res, err := reduceExpression("(λx.x_x)_((λy.y)_z)", CALL_BY_NAME)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(z_((λy.y)_z))")
+}
+
+func happyFlowCallByNeedSharesArgument(t *testing.T) {
+	// act
+	res, err := reduceExpression("(λx.x_x)_((λy.y)_z)", CALL_BY_NEED)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(z_z)")
+}
+
+func happyFlowCallByValueReducesArgumentFirst(t *testing.T) {
+	// act
+	res, err := reduceExpression("(λx.λw.x_x)_((λy.y)_z)", CALL_BY_VALUE)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λw.(z_z))")
+}
+
+func negativeFlowUnknownStrategy(t *testing.T) {
+	// act
+	_, err := reduceExpression("x", "lazy")
+
+	// assert
+	assert.ErrorContains(t, err, "unknown reduction strategy")
+}
@@ -0,0 +1,203 @@
+package syntactical_analyzer
+
+import (
+	"math-parser/pkg/entity"
+)
+
+// freeVariables collects the variables of t that are not bound by an enclosing abstraction or let.
+func freeVariables(t entity.Term) map[string]bool {
+	res := map[string]bool{}
+	collectFreeVariables(t, map[string]int{}, res)
+	return res
+}
+
+func collectFreeVariables(t entity.Term, bound map[string]int, res map[string]bool) {
+	switch t := t.(type) {
+	case *entity.Var:
+		if bound[t.Name] == 0 {
+			res[t.Name] = true
+		}
+	case *entity.Abs:
+		bound[t.Param]++
+		collectFreeVariables(t.Body, bound, res)
+		bound[t.Param]--
+	case *entity.App:
+		collectFreeVariables(t.Fun, bound, res)
+		collectFreeVariables(t.Arg, bound, res)
+	case *entity.Let:
+		if !t.Recursive {
+			collectFreeVariables(t.Value, bound, res)
+		}
+		bound[t.Name]++
+		if t.Recursive {
+			collectFreeVariables(t.Value, bound, res)
+		}
+		collectFreeVariables(t.Body, bound, res)
+		bound[t.Name]--
+	}
+}
+
+// freshVariable primes base until it clashes with none of the used names.
+func freshVariable(base string, used map[string]bool) string {
+	name := base + "'"
+	for used[name] {
+		name += "'"
+	}
+	return name
+}
+
+// substitute replaces the free occurrences of x in t with s, renaming binders of t
+// that would otherwise capture free variables of s. Sub-terms without free
+// occurrences of x are returned as is, so the result shares them with t.
+func substitute(t entity.Term, x string, s entity.Term) entity.Term {
+	switch t := t.(type) {
+	case *entity.Var:
+		if t.Name == x {
+			return s
+		}
+		return t
+	case *entity.Abs:
+		if t.Param == x || !freeVariables(t.Body)[x] {
+			return t
+		}
+		param, body := t.Param, t.Body
+		if fv := freeVariables(s); fv[param] {
+			used := freeVariables(body)
+			for name := range fv {
+				used[name] = true
+			}
+			param = freshVariable(t.Param, used)
+			body = renameFree(body, t.Param, param)
+		}
+		return t.Rebuild(param, substitute(body, x, s))
+	case *entity.App:
+		fun, arg := substitute(t.Fun, x, s), substitute(t.Arg, x, s)
+		if fun == t.Fun && arg == t.Arg {
+			return t
+		}
+		return t.Rebuild(fun, arg)
+	case *entity.Let:
+		name, value, body := t.Name, t.Value, t.Body
+		if fv := freeVariables(s); name != x && fv[name] && bindsFree(t, x) {
+			used := freeVariables(t)
+			for n := range fv {
+				used[n] = true
+			}
+			name = freshVariable(t.Name, used)
+			value, body = renameBinder(t, name)
+		}
+		if !t.Recursive || name != x {
+			value = substitute(value, x, s)
+		}
+		if name != x {
+			body = substitute(body, x, s)
+		}
+		return rebuildLet(t, name, value, body)
+	default:
+		return t
+	}
+}
+
+// renameFree renames the free occurrences of x in t to y, each of them keeping its span.
+// Like substitute, it renames the binders of t that would capture y.
+func renameFree(t entity.Term, x string, y string) entity.Term {
+	switch t := t.(type) {
+	case *entity.Var:
+		if t.Name == x {
+			return t.Rename(y)
+		}
+		return t
+	case *entity.Abs:
+		if t.Param == x || !freeVariables(t.Body)[x] {
+			return t
+		}
+		param, body := t.Param, t.Body
+		if param == y {
+			used := freeVariables(body)
+			used[y] = true
+			param = freshVariable(y, used)
+			body = renameFree(body, y, param)
+		}
+		return t.Rebuild(param, renameFree(body, x, y))
+	case *entity.App:
+		fun, arg := renameFree(t.Fun, x, y), renameFree(t.Arg, x, y)
+		if fun == t.Fun && arg == t.Arg {
+			return t
+		}
+		return t.Rebuild(fun, arg)
+	case *entity.Let:
+		name, value, body := t.Name, t.Value, t.Body
+		if name != x && name == y && bindsFree(t, x) {
+			used := freeVariables(t)
+			used[y] = true
+			name = freshVariable(y, used)
+			value, body = renameBinder(t, name)
+		}
+		if !t.Recursive || name != x {
+			value = renameFree(value, x, y)
+		}
+		if name != x {
+			body = renameFree(body, x, y)
+		}
+		return rebuildLet(t, name, value, body)
+	default:
+		return t
+	}
+}
+
+// bindsFree tells whether x occurs free in the scope of the binder of t: its body, and its value
+// too when t is recursive.
+func bindsFree(t *entity.Let, x string) bool {
+	return freeVariables(t.Body)[x] || t.Recursive && freeVariables(t.Value)[x]
+}
+
+// renameBinder returns the value and the body of t with the variable it binds renamed to name.
+func renameBinder(t *entity.Let, name string) (entity.Term, entity.Term) {
+	value := t.Value
+	if t.Recursive {
+		value = renameFree(value, t.Name, name)
+	}
+	return value, renameFree(t.Body, t.Name, name)
+}
+
+// rebuildLet returns t if name, value and body are its own, or a let binding them read from the
+// same place as t otherwise.
+func rebuildLet(t *entity.Let, name string, value entity.Term, body entity.Term) entity.Term {
+	if name == t.Name && value == t.Value && body == t.Body {
+		return t
+	}
+	return &entity.Let{Name: name, Value: value, Body: body, Recursive: t.Recursive, Span: t.Span}
+}
+
+// avoidCapture renames the binders of t that would capture a variable of fv once the
+// free occurrences of x are substituted.
+func avoidCapture(t entity.Term, x string, fv map[string]bool) entity.Term {
+	switch t := t.(type) {
+	case *entity.Abs:
+		if t.Param == x || !freeVariables(t.Body)[x] {
+			return t
+		}
+		param, body := t.Param, t.Body
+		if fv[param] {
+			used := freeVariables(body)
+			for name := range fv {
+				used[name] = true
+			}
+			param = freshVariable(t.Param, used)
+			body = renameFree(body, t.Param, param)
+		}
+		body = avoidCapture(body, x, fv)
+		if param == t.Param && body == t.Body {
+			return t
+		}
+		return t.Rebuild(param, body)
+	case *entity.App:
+		fun, arg := avoidCapture(t.Fun, x, fv), avoidCapture(t.Arg, x, fv)
+		if fun == t.Fun && arg == t.Arg {
+			return t
+		}
+		return t.Rebuild(fun, arg)
+	default:
+		return t
+	}
+}
@@ -0,0 +1,112 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Sugar(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Desugar multi-parameter abstraction",
+			scenario: happyFlowDesugarMultiParameterAbstraction,
+		},
+		{
+			name:     "Happy flow. Desugar single-letter parameters",
+			scenario: happyFlowDesugarSingleLetterParameters,
+		},
+		{
+			name:     "Happy flow. Desugar ASCII lambda with juxtaposition",
+			scenario: happyFlowDesugarAsciiLambdaWithJuxtaposition,
+		},
+		{
+			name:     "Negative flow. Report missing dot after parameters",
+			scenario: negativeFlowReportMissingDotAfterParameters,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowDesugarMultiParameterAbstraction(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx y z.x_z")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx.(λy.(λz.(x_z))))")
+	assert.Equal(t, entity.SpanOf(ast.Term()).String(), "1:1-1:11")
+	assert.Equal(t, entity.SpanOf(ast.Term().(*entity.Abs).Body).String(), "1:4-1:11")
+}
+
+func happyFlowDesugarSingleLetterParameters(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	config := lexical_analysis.DefaultConfig()
+	config.Identifiers.SingleLetter = true
+	automata := lexical_analysis.NewConfiguredAutomata(config)
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λxyz.x_z")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λx.(λy.(λz.(x_z))))")
+}
+
+func happyFlowDesugarAsciiLambdaWithJuxtaposition(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize(`(\f x. f (f x)) (^y.y) a`)
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+	normal, err := parser.Reduce(ast, NewNormalOrderStrategy())
+	normalRes, err := parser.Unparse(normal)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λf.λx.f (f x)) (λy.y) a")
+	assert.Equal(t, normalRes, "a")
+}
+
+func negativeFlowReportMissingDotAfterParameters(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize(`\x y`)
+	_, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err.Error(), "1:5: error[P002]: expected ., variable or :, found end of input")
+}
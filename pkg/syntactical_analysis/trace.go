@@ -0,0 +1,73 @@
+package syntactical_analyzer
+
+import (
+	"fmt"
+	"math-parser/pkg/entity"
+	"strings"
+)
+
+type Rule string
+
+const (
+	ALPHA_RULE Rule = "α"
+	BETA_RULE  Rule = "β"
+	ETA_RULE   Rule = "η"
+	// DELTA_RULE replaces a name with the term it is defined as, or a primitive application with its value.
+	DELTA_RULE Rule = "δ"
+)
+
+// Position addresses a sub-term by the child indices leading to it from the root:
+// 0 is the body of an abstraction or the function of an application, 1 is the argument.
+type Position []int
+
+func (p Position) Child(i int) Position {
+	return append(append(Position{}, p...), i)
+}
+
+func (p Position) String() string {
+	if len(p) == 0 {
+		return EPSILON
+	}
+	res := make([]string, len(p))
+	for i, c := range p {
+		res[i] = fmt.Sprint(c)
+	}
+	return strings.Join(res, ".")
+}
+
+// Redex describes a rewriting step: where it happened and which rule was used.
+type Redex struct {
+	Position Position
+	Rule     Rule
+	// Span is the source range of the rewritten node, zero when it was built by an earlier step.
+	Span entity.Span
+}
+
+// TraceStep is a term of a derivation together with the redex that produced it.
+// The redex of the first step is nil.
+type TraceStep struct {
+	Term  string
+	Redex *Redex
+}
+
+func (l *lL1PredictableParser) Trace(ast entity.Ast, strategy ReductionStrategy) ([]TraceStep, error) {
+	term, err := l.Unparse(ast)
+	if err != nil {
+		return nil, err
+	}
+	res := []TraceStep{{Term: term}}
+	_, err = l.normalize(l.ctx, ast.Term(), NormalizationOptions{Strategy: strategy}.withDefaults(), func(t entity.Term, redex *Redex) error {
+		term, err := l.Unparse(entity.NewAst(t))
+		if err != nil {
+			return err
+		}
+		res = append(res, TraceStep{Term: term, Redex: redex})
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	l.logging.Debugf("traced %d %s reduction steps", len(res)-1, strategy.Name())
+	return res, nil
+}
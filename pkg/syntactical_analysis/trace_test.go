@@ -0,0 +1,152 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_Trace(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Trace normal order reduction",
+			scenario: happyFlowTraceNormalOrder,
+		},
+		{
+			name:     "Happy flow. Trace alpha step before capturing substitution",
+			scenario: happyFlowTraceAlphaStep,
+		},
+		{
+			name:     "Happy flow. Trace source spans of redexes",
+			scenario: happyFlowTraceSourceSpans,
+		},
+		{
+			name:     "Happy flow. Trace term in normal form",
+			scenario: happyFlowTraceNormalForm,
+		},
+		{
+			name:     "Happy flow. Trace in juxtaposition notation",
+			scenario: happyFlowTraceJuxtaposition,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowTraceNormalOrder(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x_x)_((λy.y)_z)")
+	ast, err := parser.Parse(tk)
+	steps, err := parser.Trace(ast, NewNormalOrderStrategy())
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(steps), 4)
+	assert.Equal(t, steps[0].Term, "((λx.(x_x))_((λy.y)_z))")
+	assert.Assert(t, steps[0].Redex == nil)
+	assert.Equal(t, steps[1].Term, "(((λy.y)_z)_((λy.y)_z))")
+	assert.Equal(t, steps[1].Redex.Rule, BETA_RULE)
+	assert.Equal(t, steps[1].Redex.Position.String(), "ε")
+	assert.Equal(t, steps[2].Term, "(z_((λy.y)_z))")
+	assert.Equal(t, steps[2].Redex.Position.String(), "0")
+	assert.Equal(t, steps[3].Term, "(z_z)")
+	assert.Equal(t, steps[3].Redex.Position.String(), "1")
+}
+
+func happyFlowTraceSourceSpans(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x_x)_((λy.y)_z)")
+	ast, err := parser.Parse(tk)
+	steps, err := parser.Trace(ast, NewNormalOrderStrategy())
+	res, err := parser.Reduce(ast, NewNormalOrderStrategy())
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, steps[1].Redex.Span.String(), "1:1-1:20")
+	assert.Equal(t, steps[2].Redex.Span.String(), "1:11-1:19")
+	assert.Equal(t, steps[3].Redex.Span.String(), "1:11-1:19")
+	assert.Equal(t, entity.SpanOf(res.Term()).String(), "1:5-1:8")
+	assert.Equal(t, entity.SpanOf(res.Term().(*entity.App).Fun).String(), "1:18-1:19")
+}
+
+func happyFlowTraceAlphaStep(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λz.(λx.λy.x)_y")
+	ast, err := parser.Parse(tk)
+	steps, err := parser.Trace(ast, NewNormalOrderStrategy())
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(steps), 3)
+	assert.Equal(t, steps[1].Term, "(λz.((λx.(λy'.x))_y))")
+	assert.Equal(t, steps[1].Redex.Rule, ALPHA_RULE)
+	assert.Equal(t, steps[1].Redex.Position.String(), "0")
+	assert.Equal(t, steps[2].Term, "(λz.(λy'.y))")
+	assert.Equal(t, steps[2].Redex.Rule, BETA_RULE)
+}
+
+func happyFlowTraceNormalForm(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx.x_y")
+	ast, err := parser.Parse(tk)
+	steps, err := parser.Trace(ast, NewCallByValueStrategy())
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(steps), 1)
+	assert.Equal(t, steps[0].Term, "(λx.(x_y))")
+}
+
+func happyFlowTraceJuxtaposition(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("(λx.x x) ((λy.y) z)")
+	ast, err := parser.Parse(tk)
+	steps, err := parser.Trace(ast, NewNormalOrderStrategy())
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(steps), 4)
+	assert.Equal(t, steps[0].Term, "(λx.x x) ((λy.y) z)")
+	assert.Equal(t, steps[1].Term, "(λy.y) z ((λy.y) z)")
+	assert.Equal(t, steps[2].Term, "z ((λy.y) z)")
+	assert.Equal(t, steps[3].Term, "z z")
+}
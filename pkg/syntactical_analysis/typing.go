@@ -0,0 +1,176 @@
+package syntactical_analyzer
+
+import (
+	"fmt"
+	"math-parser/pkg/entity"
+	"strings"
+)
+
+// TypingRule names the rule concluding a typing judgement of the simply typed lambda calculus.
+type TypingRule string
+
+const (
+	VAR_RULE TypingRule = "Var"
+	ABS_RULE TypingRule = "Abs"
+	APP_RULE TypingRule = "App"
+	LET_RULE TypingRule = "Let"
+)
+
+// Binding is the type assumed for a variable in a typing context.
+type Binding struct {
+	Name string
+	Type entity.Type
+}
+
+func (b Binding) String() string {
+	return b.Name + COLON + b.Type.String()
+}
+
+// Derivation proves the judgement Context ⊢ Term : Type with Rule from its premises. The context
+// lists the bindings from the outermost to the innermost one, and Term is printed in the notation
+// of the parser.
+type Derivation struct {
+	Context  []Binding
+	Term     string
+	Type     entity.Type
+	Rule     TypingRule
+	Premises []*Derivation
+}
+
+// Judgement prints Context ⊢ Term : Type.
+func (d *Derivation) Judgement() string {
+	context := make([]string, len(d.Context))
+	for i, b := range d.Context {
+		context[i] = b.String()
+	}
+	res := "⊢ " + d.Term + " : " + d.Type.String()
+	if len(context) != 0 {
+		res = strings.Join(context, ", ") + " " + res
+	}
+	return res
+}
+
+// String prints the conclusion of the derivation above its premises, each one indented under it.
+func (d *Derivation) String() string {
+	var res strings.Builder
+	d.write(&res, 0)
+	return strings.TrimSuffix(res.String(), "\n")
+}
+
+func (d *Derivation) write(res *strings.Builder, indent int) {
+	fmt.Fprintf(res, "%s%s  (%s)\n", strings.Repeat(" ", indent), d.Judgement(), d.Rule)
+	for _, premise := range d.Premises {
+		premise.write(res, indent+DEFAULT_INDENT)
+	}
+}
+
+// TypeCheck types the closed term of ast in the simply typed lambda calculus, every abstraction
+// being annotated with the type of its parameter, and returns the derivation of its type. A let
+// binding, kept with Config.KeepLet, gives its variable the type of its value, and a recursive one
+// cannot be typed. The errors are entity.Diagnostics pointing to the terms in error. A well typed
+// term is strongly normalizing: every reduction strategy reaches its normal form.
+func (l *lL1PredictableParser) TypeCheck(ast entity.Ast) (*Derivation, error) {
+	c := &typeChecker{print: func(t entity.Term) string {
+		res, _ := Print(entity.NewAst(t), PrintOptions{Notation: Notation{
+			Juxtaposition:    l.config.Juxtaposition,
+			RightAssociative: l.config.RightAssociative,
+		}})
+		return res
+	}}
+	res := c.check(ast.Term(), nil)
+	if len(c.diagnostics) != 0 {
+		return nil, c.diagnostics
+	}
+	if res == nil {
+		return nil, errMalformedTerm
+	}
+	l.logging.Debugf("typed %s", res.Judgement())
+	return res, nil
+}
+
+type typeChecker struct {
+	print       func(entity.Term) string
+	diagnostics entity.Diagnostics
+}
+
+// check derives the type of t in context, or records why it has none and returns nil. The
+// sub-terms of an ill-typed term are still checked, so that every error is reported: a variable
+// whose binder could not be typed is bound without a type, and has none without an error of its own.
+func (c *typeChecker) check(t entity.Term, context []Binding) *Derivation {
+	switch t := t.(type) {
+	case *entity.Var:
+		for i := len(context) - 1; i >= 0; i-- {
+			if context[i].Name == t.Name {
+				if context[i].Type == nil {
+					return nil
+				}
+				return c.derive(t, context, context[i].Type, VAR_RULE)
+			}
+		}
+		c.report(t, entity.UNBOUND_VARIABLE, "unbound variable %s", t.Name)
+		return nil
+	case *entity.Abs:
+		if t.Type == nil {
+			c.report(t, entity.MISSING_ANNOTATION, "parameter %s has no type annotation", t.Param)
+			c.check(t.Body, bind(context, t.Param, nil))
+			return nil
+		}
+		body := c.check(t.Body, bind(context, t.Param, t.Type))
+		if body == nil {
+			return nil
+		}
+		return c.derive(t, context, entity.NewArrow(t.Type, body.Type), ABS_RULE, body)
+	case *entity.App:
+		fun, arg := c.check(t.Fun, context), c.check(t.Arg, context)
+		if fun == nil || arg == nil {
+			return nil
+		}
+		arrow, ok := fun.Type.(*entity.Arrow)
+		if !ok {
+			c.report(t.Fun, entity.NOT_A_FUNCTION, "%s is applied but has type %v, which is not a function type", fun.Term, fun.Type)
+			return nil
+		}
+		if !entity.EqualTypes(arrow.From, arg.Type) {
+			c.report(t.Arg, entity.TYPE_MISMATCH, "expected an argument of type %v, found %s of type %v", arrow.From, arg.Term, arg.Type)
+			return nil
+		}
+		return c.derive(t, context, arrow.To, APP_RULE, fun, arg)
+	case *entity.Let:
+		if t.Recursive {
+			c.report(t, entity.RECURSIVE_BINDING, "recursive binding %s cannot be simply typed", t.Name)
+			c.check(t.Value, bind(context, t.Name, nil))
+			c.check(t.Body, bind(context, t.Name, nil))
+			return nil
+		}
+		value := c.check(t.Value, context)
+		if value == nil {
+			c.check(t.Body, bind(context, t.Name, nil))
+			return nil
+		}
+		body := c.check(t.Body, bind(context, t.Name, value.Type))
+		if body == nil {
+			return nil
+		}
+		return c.derive(t, context, body.Type, LET_RULE, value, body)
+	default:
+		return nil
+	}
+}
+
+func (c *typeChecker) derive(t entity.Term, context []Binding, typ entity.Type, rule TypingRule, premises ...*Derivation) *Derivation {
+	return &Derivation{Context: context, Term: c.print(t), Type: typ, Rule: rule, Premises: premises}
+}
+
+func (c *typeChecker) report(t entity.Term, code entity.Code, format string, args ...interface{}) {
+	c.diagnostics = append(c.diagnostics, &entity.Diagnostic{
+		Severity: entity.ERROR,
+		Code:     code,
+		Span:     entity.SpanOf(t),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// bind returns context extended with name of type typ, leaving context itself untouched.
+func bind(context []Binding, name string, typ entity.Type) []Binding {
+	return append(append([]Binding{}, context...), Binding{Name: name, Type: typ})
+}
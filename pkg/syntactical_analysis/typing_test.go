@@ -0,0 +1,174 @@
+package syntactical_analyzer
+
+import (
+	"context"
+	"gotest.tools/assert"
+	"math-parser/pkg/entity"
+	"math-parser/pkg/lexical_analysis"
+	"math-parser/pkg/utils/logging"
+	"testing"
+)
+
+func TestLL1PredictableParser_TypeCheck(t *testing.T) {
+	var tests = []struct {
+		name     string
+		scenario func(*testing.T)
+	}{
+		{
+			name:     "Happy flow. Parse type annotations",
+			scenario: happyFlowParseTypeAnnotations,
+		},
+		{
+			name:     "Happy flow. Derive type of annotated term",
+			scenario: happyFlowTypeCheckDerivation,
+		},
+		{
+			name:     "Happy flow. Type let binding",
+			scenario: happyFlowTypeCheckLet,
+		},
+		{
+			name:     "Negative flow. Report every type error with its position",
+			scenario: negativeFlowTypeCheckErrors,
+		},
+		{
+			name:     "Negative flow. Report missing annotation",
+			scenario: negativeFlowTypeCheckMissingAnnotation,
+		},
+		{
+			name:     "Negative flow. Report incomplete type",
+			scenario: negativeFlowParseIncompleteType,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		t.Run(test.name, test.scenario)
+	}
+}
+
+func happyFlowParseTypeAnnotations(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λf:(A->B)->C x:A→B.f_x")
+	ast, err := parser.Parse(tk)
+	res, err := parser.Unparse(ast)
+	ascii, err := Print(ast, PrintOptions{Notation: Notation{Ascii: true, Sugar: true}})
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res, "(λf:(A → B) → C.(λx:A → B.(f_x)))")
+	assert.Equal(t, ascii, `\f:(A -> B) -> C x:A -> B.f_x`)
+	assert.Equal(t, ast.Term().(*entity.Abs).Type.(*entity.Arrow).From.String(), "A → B")
+	assert.Equal(t, entity.SpanOf(ast.Term()).String(), "1:1-1:23")
+}
+
+func happyFlowTypeCheckDerivation(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("λf:A→A x:A.f (f x)")
+	ast, err := parser.Parse(tk)
+	res, err := parser.TypeCheck(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res.Type.String(), "(A → A) → A → A")
+	assert.Equal(t, res.String(), ""+
+		"⊢ λf:A → A.λx:A.f (f x) : (A → A) → A → A  (Abs)\n"+
+		"  f:A → A ⊢ λx:A.f (f x) : A → A  (Abs)\n"+
+		"    f:A → A, x:A ⊢ f (f x) : A  (App)\n"+
+		"      f:A → A, x:A ⊢ f : A → A  (Var)\n"+
+		"      f:A → A, x:A ⊢ f x : A  (App)\n"+
+		"        f:A → A, x:A ⊢ f : A → A  (Var)\n"+
+		"        f:A → A, x:A ⊢ x : A  (Var)")
+}
+
+func happyFlowTypeCheckLet(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true, KeepLet: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("let twice f:o→o x:o = f (f x) in twice (λy:o.y)")
+	ast, err := parser.Parse(tk)
+	res, err := parser.TypeCheck(ast)
+
+	// assert
+	assert.Equal(t, err, nil)
+	assert.Equal(t, res.Rule, LET_RULE)
+	assert.Equal(t, res.Type.String(), "o → o")
+	assert.Equal(t, res.Premises[1].Judgement(), "twice:(o → o) → o → o ⊢ twice λy:o.y : o → o")
+}
+
+func negativeFlowTypeCheckErrors(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewConfiguredLL1PredictableParser(ctx, Config{Juxtaposition: true, KeepLet: true})
+
+	// act
+	tk, _ := analyzer.Tokenize("λx:A f:B→B.(f x) (x x) y (letrec g = g in g)")
+	ast, _ := parser.Parse(tk)
+	_, err := parser.TypeCheck(ast)
+
+	// assert
+	diagnostics, ok := err.(entity.Diagnostics)
+	assert.Assert(t, ok)
+	assert.Equal(t, diagnostics[0].Code, entity.TYPE_MISMATCH)
+	assert.Equal(t, diagnostics[0].Span.String(), "1:15-1:16")
+	assert.Equal(t, err.Error(), ""+
+		"1:15: error[T003]: expected an argument of type B, found x of type A\n"+
+		"1:19: error[T004]: x is applied but has type A, which is not a function type\n"+
+		"1:24: error[T001]: unbound variable y\n"+
+		"1:27: error[T005]: recursive binding g cannot be simply typed")
+}
+
+func negativeFlowTypeCheckMissingAnnotation(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx:A.λy.x")
+	ast, _ := parser.Parse(tk)
+	_, err := parser.TypeCheck(ast)
+	tk, _ = analyzer.Tokenize("λx.x_y")
+	ast, _ = parser.Parse(tk)
+	_, bodyErr := parser.TypeCheck(ast)
+
+	// assert
+	assert.Equal(t, err.Error(), "1:6: error[T002]: parameter y has no type annotation")
+	assert.Equal(t, bodyErr.Error(), ""+
+		"1:1: error[T002]: parameter x has no type annotation\n"+
+		"1:6: error[T001]: unbound variable y")
+}
+
+func negativeFlowParseIncompleteType(t *testing.T) {
+	// arrange
+	ctx := context.WithValue(context.Background(), "logger", logging.NewBuiltinLogger())
+	automata := lexical_analysis.NewAutomata()
+	analyzer := lexical_analysis.NewLexicalAnalyzer(ctx, automata)
+	parser := NewLL1PredictableParser(ctx)
+
+	// act
+	tk, _ := analyzer.Tokenize("λx:A→.x")
+	ast, err := parser.Parse(tk)
+
+	// assert
+	assert.Equal(t, err.Error(), "1:6: error[P001]: expected variable or (, found .")
+	assert.Assert(t, ast != nil)
+}